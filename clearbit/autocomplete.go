@@ -19,27 +19,34 @@ type AutocompleteSuggestParams struct {
 	Query string `url:"query"`
 }
 
+// Validate checks the params before they are sent
+func (p AutocompleteSuggestParams) Validate() error {
+	return required("query", p.Query)
+}
+
 // AutocompleteService gives access to the Autocomplete API.
 //
 // Company Autocomplete is a free API that lets you auto-complete company names
 // and retrieve logo and domain information.
 type AutocompleteService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newAutocompleteService(sling *sling.Sling, baseURL string) *AutocompleteService {
+func newAutocompleteService(sling *sling.Sling, baseURL string, d *dispatcher) *AutocompleteService {
 	return &AutocompleteService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/companies/").Set("Authorization", ""),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/companies/").Set("Authorization", ""),
+		dispatcher: d,
 	}
 }
 
 // Suggest lets you auto-complete company names and retrieve logo and domain
 // information
-func (s *AutocompleteService) Suggest(params AutocompleteSuggestParams) ([]AutocompleteItem, *http.Response, error) {
+func (s *AutocompleteService) Suggest(params AutocompleteSuggestParams, opts ...CallOption) ([]AutocompleteItem, *http.Response, error) {
 	items := new([]AutocompleteItem)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("suggest").QueryStruct(params).Receive(items, ae)
-	return *items, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceAutocomplete, Operation: "suggest", Params: params, Result: items}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("suggest").QueryStruct(params), opts)
+	return *items, resp, err
 }
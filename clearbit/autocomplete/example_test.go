@@ -0,0 +1,62 @@
+package autocomplete_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+	"github.com/clearbit/clearbit-go/clearbit/autocomplete"
+)
+
+// countingAutocompleter counts the calls made to the Autocomplete API
+type countingAutocompleter struct {
+	clearbit.Autocompleter
+	calls int
+}
+
+func (c *countingAutocompleter) Suggest(params clearbit.AutocompleteSuggestParams, opts ...clearbit.CallOption) ([]clearbit.AutocompleteItem, *http.Response, error) {
+	c.calls++
+	return c.Autocompleter.Suggest(params, opts...)
+}
+
+func ExampleNewHandler_output() {
+	api := &countingAutocompleter{Autocompleter: &clearbit.FakeAutocompleter{
+		Suggestions: map[string][]clearbit.AutocompleteItem{"str": {
+			{Name: "Stripe", Domain: "stripe.com"},
+			{Name: "Strava", Domain: "strava.com"},
+		}},
+	}}
+
+	handler := autocomplete.NewHandler(api,
+		autocomplete.WithCORS("https://app.example.com"),
+		autocomplete.WithRateLimit(1, 2),
+	)
+
+	get := func(query string) {
+		r := httptest.NewRequest("GET", "/suggest?query="+query, nil)
+		r.Header.Set("Origin", "https://app.example.com")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		body, _ := ioutil.ReadAll(w.Body)
+		fmt.Print(w.Code, " ", w.Header().Get("Access-Control-Allow-Origin"), " ", string(body))
+	}
+
+	get("s")    // too short, the API is not called
+	get("str")  // calls the API
+	get("Stri") // filtered from the results of "str"
+	get("stra") // cache hits are not rate limited
+	get("acme") // calls the API
+	get("zoom") // over the rate limit
+	fmt.Println("API calls:", api.calls)
+
+	// Output:
+	// 200 https://app.example.com []
+	// 200 https://app.example.com [{"domain":"stripe.com","logo":"","name":"Stripe"},{"domain":"strava.com","logo":"","name":"Strava"}]
+	// 200 https://app.example.com [{"domain":"stripe.com","logo":"","name":"Stripe"}]
+	// 200 https://app.example.com [{"domain":"strava.com","logo":"","name":"Strava"}]
+	// 200 https://app.example.com []
+	// 429 https://app.example.com {"error":"rate limit exceeded"}
+	// API calls: 2
+}
@@ -0,0 +1,396 @@
+/*
+Package autocomplete serves company name suggestions from the Clearbit
+Autocomplete API to front-ends, so they do not call Clearbit directly.
+
+	client := clearbit.NewClient()
+	http.Handle("/suggest", autocomplete.NewHandler(client.Autocomplete,
+		autocomplete.WithCORS("https://app.example.com"),
+		autocomplete.WithRateLimit(5, 20),
+	))
+
+The handler answers GET /suggest?query=stri with the JSON array of
+clearbit.AutocompleteItem returned by the API. Results are cached, and the
+results of a prefix are reused for the longer queries when they hold every
+company matching it.
+*/
+package autocomplete
+
+import (
+	"container/list"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+)
+
+// Defaults of the Handler options
+const (
+	DefaultMinQueryLength = 2
+	DefaultCacheSize      = 1000
+	DefaultCacheTTL       = 10 * time.Minute
+	// DefaultPageSize is the number of suggestions returned by the API. A
+	// result holding fewer suggestions lists every company matching the
+	// query.
+	DefaultPageSize = 5
+)
+
+// Handler is an http.Handler proxying the Autocomplete API
+type Handler struct {
+	autocompleter  clearbit.Autocompleter
+	minQueryLength int
+	pageSize       int
+	origins        map[string]bool
+	clientKey      func(r *http.Request) string
+
+	cache   *cache
+	limiter *limiter
+}
+
+// Option is an option passed to the NewHandler function used to change the
+// handler configuration
+type Option func(*Handler)
+
+// WithMinQueryLength sets the length under which queries get no suggestions
+// without calling the API, DefaultMinQueryLength by default.
+func WithMinQueryLength(n int) Option {
+	return func(h *Handler) {
+		h.minQueryLength = n
+	}
+}
+
+// WithCache sets the number of queries cached and for how long, by default
+// DefaultCacheSize and DefaultCacheTTL. A size of 0 disables the cache.
+func WithCache(size int, ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.cache = newCache(size, ttl)
+	}
+}
+
+// WithPageSize overrides DefaultPageSize, in case the API returns more
+// suggestions per query.
+func WithPageSize(n int) Option {
+	return func(h *Handler) {
+		h.pageSize = n
+	}
+}
+
+// WithRateLimit limits the queries of each client sent to the Autocomplete
+// API to perSecond, allowing bursts of up to burst queries. Queries answered
+// from the cache are not limited. Queries over the limit are answered with
+// 429 Too Many Requests.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(h *Handler) {
+		h.limiter = newLimiter(perSecond, burst)
+	}
+}
+
+// WithClientKey sets the function identifying the client of a request for
+// rate limiting, by default its remote IP address. Use it to rate limit
+// authenticated users, or clients behind a proxy.
+func WithClientKey(key func(r *http.Request) string) Option {
+	return func(h *Handler) {
+		h.clientKey = key
+	}
+}
+
+// WithCORS allows the given origins to call the handler from a browser. The
+// origin "*" allows every origin.
+func WithCORS(origins ...string) Option {
+	return func(h *Handler) {
+		for _, origin := range origins {
+			h.origins[origin] = true
+		}
+	}
+}
+
+// NewHandler returns a Handler getting its suggestions from a, usually the
+// Autocomplete field of a clearbit.Client.
+func NewHandler(a clearbit.Autocompleter, options ...Option) *Handler {
+	h := &Handler{
+		autocompleter:  a,
+		minQueryLength: DefaultMinQueryLength,
+		pageSize:       DefaultPageSize,
+		origins:        map[string]bool{},
+		clientKey:      remoteIP,
+		cache:          newCache(DefaultCacheSize, DefaultCacheTTL),
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// ServeHTTP answers GET requests with the suggestions for their query
+// parameter
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.cors(w, r)
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet, http.MethodHead:
+	default:
+		w.Header().Set("Allow", "GET, HEAD, OPTIONS")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := normalize(r.URL.Query().Get("query"))
+	if len([]rune(query)) < h.minQueryLength {
+		writeJSON(w, http.StatusOK, []clearbit.AutocompleteItem{})
+		return
+	}
+
+	// cache hits make no upstream request, so only misses are rate limited
+	if items, ok := h.cached(query); ok {
+		writeJSON(w, http.StatusOK, items)
+		return
+	}
+
+	if h.limiter != nil {
+		if wait := h.limiter.reserve(h.clientKey(r)); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+	}
+
+	items, _, err := h.autocompleter.Suggest(clearbit.AutocompleteSuggestParams{Query: query},
+		clearbit.WithContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "autocomplete unavailable")
+		return
+	}
+	if items == nil {
+		items = []clearbit.AutocompleteItem{}
+	}
+	h.cache.put(query, items)
+	writeJSON(w, http.StatusOK, items)
+}
+
+// cached returns the suggestions of query from the cache, either stored for
+// query or filtered from the complete suggestions of one of its prefixes.
+func (h *Handler) cached(query string) ([]clearbit.AutocompleteItem, bool) {
+	if items, ok := h.cache.get(query); ok {
+		return items, true
+	}
+
+	runes := []rune(query)
+	for n := len(runes) - 1; n >= h.minQueryLength; n-- {
+		items, ok := h.cache.get(string(runes[:n]))
+		if !ok {
+			continue
+		}
+		if len(items) >= h.pageSize {
+			// the API may know more companies than it returned
+			return nil, false
+		}
+		matching := []clearbit.AutocompleteItem{}
+		for _, item := range items {
+			if matches(item, query) {
+				matching = append(matching, item)
+			}
+		}
+		h.cache.put(query, matching)
+		return matching, true
+	}
+	return nil, false
+}
+
+// cors sets the CORS headers of the response when the origin is allowed
+func (h *Handler) cors(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(h.origins) == 0 {
+		return
+	}
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	switch {
+	case h.origins["*"]:
+		header.Set("Access-Control-Allow-Origin", "*")
+	case h.origins[origin]:
+		header.Set("Access-Control-Allow-Origin", origin)
+	default:
+		return
+	}
+	header.Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+	header.Set("Access-Control-Max-Age", "86400")
+}
+
+// matches returns true if a word of the name of item, or its domain, starts
+// with query
+func matches(item clearbit.AutocompleteItem, query string) bool {
+	if strings.HasPrefix(strings.ToLower(item.Domain), query) {
+		return true
+	}
+	name := strings.ToLower(item.Name)
+	if strings.HasPrefix(name, query) {
+		return true
+	}
+	for i, r := range name {
+		if r == ' ' && strings.HasPrefix(name[i+1:], query) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// remoteIP returns the IP address of the client of r
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// cache is an LRU cache of suggestions
+type cache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	query   string
+	items   []clearbit.AutocompleteItem
+	expires time.Time
+}
+
+func newCache(size int, ttl time.Duration) *cache {
+	return &cache{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *cache) get(query string) ([]clearbit.AutocompleteItem, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[query]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if c.now().After(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, query)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return entry.items, true
+}
+
+func (c *cache) put(query string, items []clearbit.AutocompleteItem) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{query: query, items: items, expires: c.now().Add(c.ttl)}
+	if e, ok := c.entries[query]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[query] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).query)
+	}
+}
+
+// limiter is a token bucket per client
+type limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(perSecond float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:    perSecond,
+		burst:   float64(burst),
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// reserve takes a token from the bucket of client and returns 0, or how long
+// to wait for the next token when the bucket is empty
+func (l *limiter) reserve(client string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[client]
+	if !ok {
+		// forget the clients whose bucket filled up again so the map does
+		// not grow forever
+		if len(l.buckets) >= 10000 {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Hour
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+func (l *limiter) prune(now time.Time) {
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, client)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package clearbit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/clearbit/clearbit-go/clearbit/internal/statefile"
+)
+
+// BudgetCombined is the budget service of the combined Person and Company
+// lookups made by PersonService.FindCombined, counted apart from the Person
+// ones.
+const BudgetCombined = "combined"
+
+// billableServices are the services consuming credits
+var billableServices = map[string]bool{
+	ServicePerson:     true,
+	BudgetCombined:    true,
+	ServiceCompany:    true,
+	ServiceProspector: true,
+	ServiceReveal:     true,
+}
+
+// budgetService returns the service a call is counted under by a Budget
+func budgetService(call *Call) string {
+	if call.Service == ServicePerson && call.Operation == "combined" {
+		return BudgetCombined
+	}
+	return call.Service
+}
+
+// ErrBudgetExceeded is returned, without sending the request, by the calls
+// that would go over one of the caps of a Budget.
+type ErrBudgetExceeded struct {
+	Service string
+	// Period is either "daily" or "monthly"
+	Period string
+	Limit  int
+	Used   int
+}
+
+func (e *ErrBudgetExceeded) Error() string {
+	return fmt.Sprintf("clearbit: %s budget exceeded for %s (%d/%d calls)", e.Period, e.Service, e.Used, e.Limit)
+}
+
+// BudgetLimits are the caps of a Budget. A zero cap means no limit.
+type BudgetLimits struct {
+	Daily   int
+	Monthly int
+}
+
+// Budget counts the billable calls made by each service and refuses the ones
+// going over the daily or monthly caps.
+//
+// Person, Combined (counted under BudgetCombined), Company, Prospector and
+// Reveal calls are billable. Calls served from a cache, dry run calls and lookups answered
+// with 404 Not Found or 202 Accepted are not counted.
+type Budget struct {
+	mu       sync.Mutex
+	limits   map[string]BudgetLimits
+	total    BudgetLimits
+	path     string
+	file     statefile.File
+	now      func() time.Time
+	counters budgetCounters
+
+	saveErrorHandler func(error)
+}
+
+// budgetCounters is the state of a Budget persisted between runs
+type budgetCounters struct {
+	Day     string         `json:"day"`
+	Month   string         `json:"month"`
+	Daily   map[string]int `json:"daily"`
+	Monthly map[string]int `json:"monthly"`
+}
+
+// BudgetOption is an option passed to the NewBudget function used to change
+// the budget configuration
+type BudgetOption func(*Budget)
+
+// WithServiceLimits caps the billable calls of one service
+func WithServiceLimits(service string, limits BudgetLimits) BudgetOption {
+	return func(b *Budget) {
+		b.limits[service] = limits
+	}
+}
+
+// WithTotalLimits caps the billable calls of all the services together
+func WithTotalLimits(limits BudgetLimits) BudgetOption {
+	return func(b *Budget) {
+		b.total = limits
+	}
+}
+
+// WithBudgetFile persists the counters to path so they survive restarts.
+// The file is read by NewBudget and written after every billable call.
+func WithBudgetFile(path string) BudgetOption {
+	return func(b *Budget) {
+		b.path = path
+	}
+}
+
+// WithSaveErrorHandler sets the function called when the budget file cannot
+// be written after a billable call. The call itself still succeeds, as it was
+// billed. By default the error is logged to slog.Default().
+func WithSaveErrorHandler(handler func(error)) BudgetOption {
+	return func(b *Budget) {
+		b.saveErrorHandler = handler
+	}
+}
+
+// NewBudget returns a Budget with the given caps. Counters are reset at the
+// start of every UTC day and month.
+func NewBudget(options ...BudgetOption) (*Budget, error) {
+	b := &Budget{
+		limits: map[string]BudgetLimits{},
+		now:    time.Now,
+	}
+	for _, option := range options {
+		option(b)
+	}
+
+	if b.path != "" {
+		b.file.Path = b.path
+		data, err := ioutil.ReadFile(b.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &b.counters); err != nil {
+				return nil, fmt.Errorf("clearbit: reading budget file %s: %v", b.path, err)
+			}
+		}
+	}
+	b.roll()
+	return b, nil
+}
+
+// WithBudget enforces b on every call made by the client services
+func WithBudget(b *Budget) func(*config) {
+	return func(c *config) {
+		c.budget = b
+	}
+}
+
+// middleware returns the Middleware enforcing the budget
+func (b *Budget) middleware(next Handler) Handler {
+	return func(call *Call) (*http.Response, error) {
+		service := budgetService(call)
+		if !billableServices[service] || call.DryRun {
+			return next(call)
+		}
+		r, err := b.reserve(service)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := next(call)
+
+		if call.Cached || OutcomeOf(resp, err) != OutcomeOK {
+			b.release(service, r)
+			return resp, err
+		}
+		// the call was billed, so its result is returned even if the
+		// counters could not be saved
+		if serr := b.save(); serr != nil {
+			b.onSaveError(serr)
+		}
+		return resp, err
+	}
+}
+
+// reservation is the day and month a call was counted in
+type reservation struct {
+	day, month string
+}
+
+// reserve counts a call before sending it, so concurrent calls cannot go
+// over the caps together.
+func (b *Budget) reserve(service string) (reservation, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+	checks := []struct {
+		service string
+		period  string
+		limit   int
+		used    int
+	}{
+		{service, "daily", b.limits[service].Daily, b.counters.Daily[service]},
+		{service, "monthly", b.limits[service].Monthly, b.counters.Monthly[service]},
+		{"all services", "daily", b.total.Daily, sum(b.counters.Daily)},
+		{"all services", "monthly", b.total.Monthly, sum(b.counters.Monthly)},
+	}
+	for _, c := range checks {
+		if c.limit > 0 && c.used >= c.limit {
+			return reservation{}, &ErrBudgetExceeded{Service: c.service, Period: c.period, Limit: c.limit, Used: c.used}
+		}
+	}
+
+	b.counters.Daily[service]++
+	b.counters.Monthly[service]++
+	return reservation{day: b.counters.Day, month: b.counters.Month}, nil
+}
+
+// release gives back a reservation for a call that was not billed. Nothing
+// is given back to a day or month that ended since the reservation.
+func (b *Budget) release(service string, r reservation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+	if b.counters.Day == r.day && b.counters.Daily[service] > 0 {
+		b.counters.Daily[service]--
+	}
+	if b.counters.Month == r.month && b.counters.Monthly[service] > 0 {
+		b.counters.Monthly[service]--
+	}
+}
+
+// roll resets the counters when the day or month changed. b.mu must be held.
+func (b *Budget) roll() {
+	now := b.now().UTC()
+	day, month := now.Format("2006-01-02"), now.Format("2006-01")
+
+	if b.counters.Day != day || b.counters.Daily == nil {
+		b.counters.Day = day
+		b.counters.Daily = map[string]int{}
+	}
+	if b.counters.Month != month || b.counters.Monthly == nil {
+		b.counters.Month = month
+		b.counters.Monthly = map[string]int{}
+	}
+}
+
+// onSaveError reports an error of save
+func (b *Budget) onSaveError(err error) {
+	if b.saveErrorHandler != nil {
+		b.saveErrorHandler(err)
+		return
+	}
+	slog.Default().Warn("clearbit budget not saved",
+		slog.String("path", b.path),
+		slog.String("error", err.Error()),
+	)
+}
+
+// save writes the counters to the budget file, if any
+func (b *Budget) save() error {
+	if b.path == "" {
+		return nil
+	}
+	return b.file.Save(func() ([]byte, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return json.MarshalIndent(b.counters, "", "  ")
+	})
+}
+
+// BudgetUsage is the usage of one service as reported by Budget.Usage
+type BudgetUsage struct {
+	Daily   int
+	Monthly int
+	Limits  BudgetLimits
+}
+
+// BudgetReport is a snapshot of a Budget
+type BudgetReport struct {
+	Day      string
+	Month    string
+	Services map[string]BudgetUsage
+	Total    BudgetUsage
+}
+
+// Usage returns the billable calls made so far in the current day and month
+func (b *Budget) Usage() BudgetReport {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+	report := BudgetReport{
+		Day:      b.counters.Day,
+		Month:    b.counters.Month,
+		Services: map[string]BudgetUsage{},
+		Total: BudgetUsage{
+			Daily:   sum(b.counters.Daily),
+			Monthly: sum(b.counters.Monthly),
+			Limits:  b.total,
+		},
+	}
+	for service := range billableServices {
+		report.Services[service] = BudgetUsage{
+			Daily:   b.counters.Daily[service],
+			Monthly: b.counters.Monthly[service],
+			Limits:  b.limits[service],
+		}
+	}
+	return report
+}
+
+func sum(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
@@ -0,0 +1,76 @@
+package clearbit
+
+import (
+	"context"
+	"time"
+)
+
+// CallOption is an option passed to a service method used to change a single
+// call, as opposed to the options given to NewClient.
+type CallOption func(*Call)
+
+// WithContext sends the request with ctx, so the call is aborted when ctx is
+// done.
+func WithContext(ctx context.Context) CallOption {
+	return func(c *Call) {
+		c.Context = ctx
+	}
+}
+
+// WithCallTimeout limits the time taken by the call, on top of the timeout
+// of the client.
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(c *Call) {
+		c.timeout = d
+	}
+}
+
+// WithCallAPIKey authenticates the call with apiKey instead of the key of
+// the client or of its KeyPool.
+func WithCallAPIKey(apiKey string) CallOption {
+	return func(c *Call) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithHeader adds a header to the request
+func WithHeader(key, value string) CallOption {
+	return func(c *Call) {
+		c.Header.Add(key, value)
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header of the request
+func WithIdempotencyKey(key string) CallOption {
+	return func(c *Call) {
+		c.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// WithWebhookURL asks Clearbit to post the result of a queued lookup to url
+// instead of the webhook configured on the account.
+func WithWebhookURL(url string) CallOption {
+	return func(c *Call) {
+		c.Query.Set("webhook_url", url)
+	}
+}
+
+// WithWebhookID sets the custom identifier sent back with the webhook of a
+// queued lookup.
+func WithWebhookID(id string) CallOption {
+	return func(c *Call) {
+		c.Query.Set("webhook_id", id)
+	}
+}
+
+// SkipCache sets Call.SkipCache, asking caching middleware to fetch a fresh
+// result.
+//
+// The flag is advisory: the client has no cache of its own and ignores it.
+// Only caching middleware added with WithMiddleware that checks
+// Call.SkipCache honours it.
+func SkipCache() CallOption {
+	return func(c *Call) {
+		c.SkipCache = true
+	}
+}
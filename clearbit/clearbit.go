@@ -10,15 +10,18 @@ import (
 )
 
 // Client is a Clearbit client for making Clearbit API requests.
+//
+// Each API is exposed through an interface so any of them can be replaced by
+// a fake (see FakePersonFinder and friends) or wrapped by a decorator.
 type Client struct {
-	Autocomplete *AutocompleteService
-	Person       *PersonService
-	Company      *CompanyService
-	Discovery    *DiscoveryService
-	Prospector   *ProspectorService
-	Reveal       *RevealService
-	Risk         *RiskService
-	NameToDomain *NameToDomainService
+	Autocomplete Autocompleter
+	Person       PersonFinder
+	Company      CompanyFinder
+	Discovery    DiscoverySearcher
+	Prospector   ProspectorSearcher
+	Reveal       RevealFinder
+	Risk         RiskCalculator
+	NameToDomain NameToDomainFinder
 }
 
 // config represents all the parameters available to configure a Clearbit
@@ -28,6 +31,15 @@ type config struct {
 	httpClient *http.Client
 	timeout    time.Duration
 	baseURLs   *BaseURLs
+	middleware []Middleware
+	logger     *loggerConfig
+	metrics    *Metrics
+	tracer     Tracer
+	budget     *Budget
+	dryRun     *DryRun
+	keyPool    *KeyPool
+	strict     *strictDecoder
+	validate   bool
 }
 
 // Option is an option passed to the NewClient function used to change
@@ -124,14 +136,16 @@ func NewClient(options ...Option) *Client {
 	base := sling.New().Client(c.httpClient)
 	base.SetBasicAuth(c.apiKey, "")
 
+	d := newDispatcher(&c)
+
 	return &Client{
-		Autocomplete: newAutocompleteService(base.New(), c.baseURLs.Autocomplete),
-		Person:       newPersonService(base.New(), c.baseURLs.Person),
-		Company:      newCompanyService(base.New(), c.baseURLs.Company),
-		Discovery:    newDiscoveryService(base.New(), c.baseURLs.Discovery),
-		Prospector:   newProspectorService(base.New(), c.baseURLs.Prospector),
-		Reveal:       newRevealService(base.New(), c.baseURLs.Reveal),
-		Risk:         newRiskService(base.New(), c.baseURLs.Risk),
-		NameToDomain: newNameToDomainService(base.New(), c.baseURLs.NameToDomain),
+		Autocomplete: newAutocompleteService(base.New(), c.baseURLs.Autocomplete, d),
+		Person:       newPersonService(base.New(), c.baseURLs.Person, d),
+		Company:      newCompanyService(base.New(), c.baseURLs.Company, d),
+		Discovery:    newDiscoveryService(base.New(), c.baseURLs.Discovery, d),
+		Prospector:   newProspectorService(base.New(), c.baseURLs.Prospector, d),
+		Reveal:       newRevealService(base.New(), c.baseURLs.Reveal, d),
+		Risk:         newRiskService(base.New(), c.baseURLs.Risk, d),
+		NameToDomain: newNameToDomainService(base.New(), c.baseURLs.NameToDomain, d),
 	}
 }
@@ -1,6 +1,7 @@
 package clearbit
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
@@ -10,86 +11,177 @@ import (
 // Company contains all the company fields gathered from the Company json
 // structure. https://dashboard.clearbit.com/docs#enrichment-api-company-api
 type Company struct {
-	ID            string   `json:"id"`
-	Name          string   `json:"name"`
-	LegalName     string   `json:"legalName"`
-	Domain        string   `json:"domain"`
-	DomainAliases []string `json:"domainAliases"`
-	Site          struct {
-		PhoneNumbers   []string `json:"phoneNumbers"`
-		EmailAddresses []string `json:"emailAddresses"`
-	} `json:"site"`
-	Category struct {
-		Sector        string `json:"sector"`
-		IndustryGroup string `json:"industryGroup"`
-		Industry      string `json:"industry"`
-		SubIndustry   string `json:"subIndustry"`
-		SicCode       string `json:"sicCode"`
-		NaicsCode     string `json:"naicsCode"`
-	} `json:"category"`
-	Tags        []string `json:"tags"`
-	Description string   `json:"description"`
-	FoundedYear int      `json:"foundedYear"`
-	Location    string   `json:"location"`
-	TimeZone    string   `json:"timeZone"`
-	UtcOffset   int      `json:"utcOffset"`
-	Geo         struct {
-		StreetNumber string  `json:"streetNumber"`
-		StreetName   string  `json:"streetName"`
-		SubPremise   string  `json:"subPremise"`
-		City         string  `json:"city"`
-		PostalCode   string  `json:"postalCode"`
-		State        string  `json:"state"`
-		StateCode    string  `json:"stateCode"`
-		Country      string  `json:"country"`
-		CountryCode  string  `json:"countryCode"`
-		Lat          float64 `json:"lat"`
-		Lng          float64 `json:"lng"`
-	} `json:"geo"`
-	Logo     string `json:"logo"`
-	Facebook struct {
-		Handle string `json:"handle"`
-		Likes  int    `json:"likes"`
-	} `json:"facebook"`
-	LinkedIn struct {
-		Handle string `json:"handle"`
-	} `json:"linkedin"`
-	Twitter struct {
-		Handle    string `json:"handle"`
-		ID        string `json:"id"`
-		Bio       string `json:"bio"`
-		Followers int    `json:"followers"`
-		Following int    `json:"following"`
-		Location  string `json:"location"`
-		Site      string `json:"site"`
-		Avatar    string `json:"avatar"`
-	} `json:"twitter"`
-	Crunchbase struct {
-		Handle string `json:"handle"`
-	} `json:"crunchbase"`
-	EmailProvider bool   `json:"emailProvider"`
-	Type          string `json:"type"`
-	Ticker        string `json:"ticker"`
-	Identifiers   struct {
-		UsEIN string `json:"usEIN"`
-	} `json:"identifiers"`
-	Phone   string `json:"phone"`
-	Metrics struct {
-		AlexaUsRank            int    `json:"alexaUsRank"`
-		AlexaGlobalRank        int    `json:"alexaGlobalRank"`
-		Employees              int    `json:"employees"`
-		EmployeesRange         string `json:"employeesRange"`
-		MarketCap              int    `json:"marketCap"`
-		Raised                 int    `json:"raised"`
-		AnnualRevenue          int    `json:"annualRevenue"`
-		EstimatedAnnualRevenue string `json:"estimatedAnnualRevenue"`
-		FiscalYearEnd          int    `json:"fiscalYearEnd"`
-	} `json:"metrics"`
-	IndexedAt time.Time `json:"indexedAt"`
-	Tech      []string  `json:"tech"`
-	Parent    struct {
-		Domain string `json:"domain"`
-	} `json:"parent"`
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	LegalName     string          `json:"legalName"`
+	Domain        string          `json:"domain"`
+	DomainAliases []string        `json:"domainAliases"`
+	Site          CompanySite     `json:"site"`
+	Category      CompanyCategory `json:"category"`
+	Tags          []string        `json:"tags"`
+	Description   string          `json:"description"`
+	FoundedYear   int             `json:"foundedYear"`
+	Location      string          `json:"location"`
+	TimeZone      string          `json:"timeZone"`
+	UtcOffset     int             `json:"utcOffset"`
+	Geo           CompanyGeo      `json:"geo"`
+	Logo          string          `json:"logo"`
+	Facebook      CompanyFacebook `json:"facebook"`
+	LinkedIn      SocialHandle    `json:"linkedin"`
+	Twitter       CompanyTwitter  `json:"twitter"`
+	Crunchbase    SocialHandle    `json:"crunchbase"`
+	// EmailProvider is true for the domains of email providers, such as
+	// gmail.com. It is the only email provider attribute of the Company API,
+	// the free and disposable checks of an address being done by the Risk API.
+	EmailProvider  bool               `json:"emailProvider"`
+	Type           string             `json:"type"`
+	Ticker         string             `json:"ticker"`
+	Identifiers    CompanyIdentifiers `json:"identifiers"`
+	Phone          string             `json:"phone"`
+	Metrics        CompanyMetrics     `json:"metrics"`
+	IndexedAt      time.Time          `json:"indexedAt"`
+	Fuzzy          bool               `json:"fuzzy"`
+	Tech           []string           `json:"tech"`
+	TechCategories []string           `json:"techCategories"`
+	Parent         CompanyParent      `json:"parent"`
+	UltimateParent CompanyParent      `json:"ultimateParent"`
+	// Raw is the JSON payload the company was decoded from
+	Raw json.RawMessage `json:"-"`
+	// Extras holds the keys of Raw without a matching field
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// CompanySite describes the website of a company
+type CompanySite struct {
+	Title           string   `json:"title"`
+	H1              string   `json:"h1"`
+	MetaDescription string   `json:"metaDescription"`
+	MetaAuthor      string   `json:"metaAuthor"`
+	PhoneNumbers    []string `json:"phoneNumbers"`
+	EmailAddresses  []string `json:"emailAddresses"`
+}
+
+// CompanyCategory holds the industry classification of a company
+type CompanyCategory struct {
+	Sector          string   `json:"sector"`
+	IndustryGroup   string   `json:"industryGroup"`
+	Industry        string   `json:"industry"`
+	SubIndustry     string   `json:"subIndustry"`
+	Gics2Code       string   `json:"gics2Code"`
+	Gics4Code       string   `json:"gics4Code"`
+	Gics6Code       string   `json:"gics6Code"`
+	Gics8Code       string   `json:"gics8Code"`
+	SicCode         string   `json:"sicCode"`
+	Sic4Codes       []string `json:"sic4Codes"`
+	NaicsCode       string   `json:"naicsCode"`
+	Naics6Codes     []string `json:"naics6Codes"`
+	Naics6Codes2022 []string `json:"naics6Codes2022"`
+}
+
+// CompanyGeo is the address of the headquarters of a company
+type CompanyGeo struct {
+	StreetNumber  string  `json:"streetNumber"`
+	StreetName    string  `json:"streetName"`
+	SubPremise    string  `json:"subPremise"`
+	StreetAddress string  `json:"streetAddress"`
+	City          string  `json:"city"`
+	PostalCode    string  `json:"postalCode"`
+	State         string  `json:"state"`
+	StateCode     string  `json:"stateCode"`
+	Country       string  `json:"country"`
+	CountryCode   string  `json:"countryCode"`
+	Lat           float64 `json:"lat"`
+	Lng           float64 `json:"lng"`
+}
+
+// SocialHandle is a profile on a social network identified by its handle
+type SocialHandle struct {
+	Handle string `json:"handle"`
+}
+
+// CompanyFacebook is the Facebook page of a company
+type CompanyFacebook struct {
+	Handle string `json:"handle"`
+	Likes  int    `json:"likes"`
+}
+
+// CompanyTwitter is the Twitter account of a company
+type CompanyTwitter struct {
+	Handle    string `json:"handle"`
+	ID        string `json:"id"`
+	Bio       string `json:"bio"`
+	Followers int    `json:"followers"`
+	Following int    `json:"following"`
+	Statuses  int    `json:"statuses"`
+	Location  string `json:"location"`
+	Site      string `json:"site"`
+	Avatar    string `json:"avatar"`
+}
+
+// CompanyIdentifiers holds the registration numbers of a company
+type CompanyIdentifiers struct {
+	// UsEIN is the US Employer Identification Number
+	UsEIN string `json:"usEIN"`
+	// Others holds the other identifiers returned by the API as raw JSON,
+	// keyed by their name in the payload, as they are not all strings
+	Others map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the identifiers, filling Others with the ones
+// without a field. It never fails so an unexpected identifier cannot break
+// the decoding of the company.
+func (i *CompanyIdentifiers) UnmarshalJSON(data []byte) error {
+	*i = CompanyIdentifiers{}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil
+	}
+	for key, value := range all {
+		if string(value) == "null" {
+			continue
+		}
+		if key == "usEIN" && json.Unmarshal(value, &i.UsEIN) == nil {
+			continue
+		}
+		if i.Others == nil {
+			i.Others = map[string]json.RawMessage{}
+		}
+		i.Others[key] = value
+	}
+	return nil
+}
+
+// keepsUnknownKeys tells strict decoding that Others holds the keys without
+// a field
+func (i *CompanyIdentifiers) keepsUnknownKeys() {}
+
+// MarshalJSON encodes the identifiers, Others included
+func (i CompanyIdentifiers) MarshalJSON() ([]byte, error) {
+	all := map[string]interface{}{"usEIN": i.UsEIN}
+	for key, value := range i.Others {
+		all[key] = value
+	}
+	return json.Marshal(all)
+}
+
+// CompanyMetrics holds the size and financial figures of a company
+type CompanyMetrics struct {
+	AlexaUsRank     int `json:"alexaUsRank"`
+	AlexaGlobalRank int `json:"alexaGlobalRank"`
+	// TrafficRank is the traffic bucket of the website, e.g. "very_high"
+	TrafficRank            string `json:"trafficRank"`
+	Employees              int    `json:"employees"`
+	EmployeesRange         string `json:"employeesRange"`
+	MarketCap              int    `json:"marketCap"`
+	Raised                 int    `json:"raised"`
+	AnnualRevenue          int    `json:"annualRevenue"`
+	EstimatedAnnualRevenue string `json:"estimatedAnnualRevenue"`
+	FiscalYearEnd          int    `json:"fiscalYearEnd"`
+}
+
+// CompanyParent references the parent company of a company
+type CompanyParent struct {
+	Domain string `json:"domain"`
 }
 
 // CompanyFindParams wraps the parameters needed to interact with the Company
@@ -98,24 +190,31 @@ type CompanyFindParams struct {
 	Domain string `url:"domain,omitempty"`
 }
 
+// Validate checks the params before they are sent
+func (p CompanyFindParams) Validate() error {
+	return required("domain", p.Domain)
+}
+
 // CompanyService gives access to the Company API.
 // https://dashboard.clearbit.com/docs#enrichment-api-company-api
 type CompanyService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newCompanyService(sling *sling.Sling, baseURL string) *CompanyService {
+func newCompanyService(sling *sling.Sling, baseURL string, d *dispatcher) *CompanyService {
 	return &CompanyService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v2/companies/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v2/companies/"),
+		dispatcher: d,
 	}
 }
 
-//Find looks up a company based on its domain
-func (s *CompanyService) Find(params CompanyFindParams) (*Company, *http.Response, error) {
+// Find looks up a company based on its domain
+func (s *CompanyService) Find(params CompanyFindParams, opts ...CallOption) (*Company, *http.Response, error) {
 	item := new(Company)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("find").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceCompany, Operation: "find", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("find").QueryStruct(params), opts)
+	return item, resp, err
 }
@@ -0,0 +1,435 @@
+package clearbit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables overriding the values of a configuration profile
+const (
+	envProfile      = "CLEARBIT_PROFILE"
+	envAPIKey       = "CLEARBIT_KEY"
+	envTimeout      = "CLEARBIT_TIMEOUT"
+	envKeys         = "CLEARBIT_KEYS"
+	envKeySelection = "CLEARBIT_KEY_SELECTION"
+	envBaseURL      = "CLEARBIT_BASE_URL_"
+)
+
+// services are the valid Service names, as used in the base_urls of a
+// profile.
+var services = []string{
+	ServiceAutocomplete,
+	ServicePerson,
+	ServiceCompany,
+	ServiceDiscovery,
+	ServiceProspector,
+	ServiceReveal,
+	ServiceRisk,
+	ServiceNameToDomain,
+}
+
+// ProfileConfig is a named client configuration loaded by LoadConfig
+type ProfileConfig struct {
+	Name   string `json:"-"`
+	APIKey string `json:"api_key"`
+	// Timeout is a duration parsed by time.ParseDuration, e.g. "10s"
+	Timeout string `json:"timeout"`
+	// BaseURLs are keyed by the names accepted by WithBaseURLs
+	BaseURLs map[string]string `json:"base_urls"`
+	// Keys are the keys of a KeyPool, used instead of APIKey when set
+	Keys []ProfileKey `json:"keys"`
+	// KeySelection is either "round_robin", the default, or "priority"
+	KeySelection string `json:"key_selection"`
+}
+
+// ProfileKey is one of the keys of a profile KeyPool
+type ProfileKey struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// ConfigError lists all the problems found in a configuration
+type ConfigError struct {
+	Path     string
+	Profile  string
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("clearbit: invalid configuration %s (profile %q): %s", e.Path, e.Profile, strings.Join(e.Problems, "; "))
+}
+
+// NewClientFromConfig returns a new Client configured from a profile of the
+// configuration file at path and from the CLEARBIT_* environment variables.
+//
+// The options given take precedence over the configuration. See LoadConfig
+// for the file format.
+func NewClientFromConfig(path, profile string, options ...Option) (*Client, error) {
+	pc, err := LoadConfig(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(append(pc.Options(), options...)...), nil
+}
+
+// LoadConfig reads a profile from a configuration file, applies the
+// environment overrides and validates the result.
+//
+// Files ending in .json hold an object with the profiles keyed by name:
+//
+//	{
+//	  "default_profile": "prod",
+//	  "profiles": {
+//	    "prod": {"timeout": "10s"},
+//	    "staging": {"base_urls": {"person": "http://localhost:8080"}}
+//	  }
+//	}
+//
+// Other files use a TOML-like syntax with one section per profile:
+//
+//	default_profile = "prod"
+//
+//	[prod]
+//	timeout = "10s"
+//
+//	[staging]
+//	base_urls.person = "http://localhost:8080"
+//	keys.marketing = "sk_1234567890123123"
+//
+// Values may be quoted, with the escapes of TOML basic strings such as \"
+// and \\, and followed by a # comment.
+//
+// The profile defaults to CLEARBIT_PROFILE, then to the default_profile of
+// the file. Every field can be overridden from the environment with
+// CLEARBIT_KEY, CLEARBIT_TIMEOUT, CLEARBIT_KEYS ("name=key,name=key"),
+// CLEARBIT_KEY_SELECTION and CLEARBIT_BASE_URL_<SERVICE>, e.g.
+// CLEARBIT_BASE_URL_NAME_TO_DOMAIN.
+func LoadConfig(path, profile string) (*ProfileConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultProfile string
+	var profiles map[string]*ProfileConfig
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		defaultProfile, profiles, err = parseJSONConfig(data)
+	} else {
+		defaultProfile, profiles, err = parseTOMLConfig(data)
+	}
+	if err != nil {
+		return nil, &ConfigError{Path: path, Profile: profile, Problems: []string{err.Error()}}
+	}
+
+	if profile == "" {
+		profile = os.Getenv(envProfile)
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+	if profile == "" {
+		return nil, &ConfigError{Path: path, Problems: []string{
+			fmt.Sprintf("no profile selected, pass one, set %s or a default_profile", envProfile),
+		}}
+	}
+	pc, ok := profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, &ConfigError{Path: path, Profile: profile, Problems: []string{
+			fmt.Sprintf("unknown profile, available profiles are %s", strings.Join(names, ", ")),
+		}}
+	}
+	pc.Name = profile
+
+	problems := pc.applyEnv()
+	problems = append(problems, pc.validate()...)
+	if len(problems) > 0 {
+		return nil, &ConfigError{Path: path, Profile: profile, Problems: problems}
+	}
+	return pc, nil
+}
+
+// Options returns the client options of the profile
+func (pc *ProfileConfig) Options() []Option {
+	var options []Option
+	if pc.APIKey != "" {
+		options = append(options, WithAPIKey(pc.APIKey))
+	}
+	if d, err := time.ParseDuration(pc.Timeout); err == nil && pc.Timeout != "" {
+		options = append(options, WithTimeout(d))
+	}
+	if len(pc.BaseURLs) > 0 {
+		urls := map[string]string{}
+		for name, u := range pc.BaseURLs {
+			urls[canonicalService(name)] = u
+		}
+		options = append(options, WithBaseURLs(urls))
+	}
+	if len(pc.Keys) > 0 {
+		selection := RoundRobin
+		if pc.KeySelection == "priority" {
+			selection = Priority
+		}
+		pool := NewKeyPool(selection)
+		for _, k := range pc.Keys {
+			pool.Add(k.Name, k.Key)
+		}
+		options = append(options, WithKeyPool(pool))
+	}
+	return options
+}
+
+// set sets a field of the profile from its flattened name, e.g.
+// "base_urls.person"
+func (pc *ProfileConfig) set(key, value string) error {
+	switch {
+	case key == "api_key":
+		pc.APIKey = value
+	case key == "timeout":
+		pc.Timeout = value
+	case key == "key_selection":
+		pc.KeySelection = value
+	case strings.HasPrefix(key, "base_urls."):
+		if pc.BaseURLs == nil {
+			pc.BaseURLs = map[string]string{}
+		}
+		pc.BaseURLs[strings.TrimPrefix(key, "base_urls.")] = value
+	case strings.HasPrefix(key, "keys."):
+		pc.Keys = append(pc.Keys, ProfileKey{Name: strings.TrimPrefix(key, "keys."), Key: value})
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return nil
+}
+
+// applyEnv overrides the profile with the CLEARBIT_* environment variables
+func (pc *ProfileConfig) applyEnv() []string {
+	var problems []string
+
+	if v, ok := os.LookupEnv(envAPIKey); ok {
+		pc.APIKey = v
+	}
+	if v, ok := os.LookupEnv(envTimeout); ok {
+		pc.Timeout = v
+	}
+	if v, ok := os.LookupEnv(envKeySelection); ok {
+		pc.KeySelection = v
+	}
+	if v, ok := os.LookupEnv(envKeys); ok {
+		pc.Keys = nil
+		for _, pair := range strings.Split(v, ",") {
+			name, key, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if !found {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a name=key pair", envKeys, pair))
+				continue
+			}
+			pc.Keys = append(pc.Keys, ProfileKey{Name: name, Key: key})
+		}
+	}
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, envBaseURL) {
+			continue
+		}
+		service := canonicalService(strings.TrimPrefix(name, envBaseURL))
+		if service == "" {
+			problems = append(problems, fmt.Sprintf("%s: unknown service, valid services are %s", name, strings.Join(services, ", ")))
+			continue
+		}
+		// drop any spelling of the same service coming from the file
+		for k := range pc.BaseURLs {
+			if canonicalService(k) == service {
+				delete(pc.BaseURLs, k)
+			}
+		}
+		if pc.BaseURLs == nil {
+			pc.BaseURLs = map[string]string{}
+		}
+		pc.BaseURLs[service] = value
+	}
+	return problems
+}
+
+// validate returns a description of every problem of the profile
+func (pc *ProfileConfig) validate() []string {
+	var problems []string
+
+	if pc.APIKey == "" && len(pc.Keys) == 0 {
+		problems = append(problems, fmt.Sprintf("no API key, set api_key, keys, %s or %s", envAPIKey, envKeys))
+	}
+	if pc.Timeout != "" {
+		if d, err := time.ParseDuration(pc.Timeout); err != nil {
+			problems = append(problems, fmt.Sprintf("timeout %q is not a duration such as \"10s\"", pc.Timeout))
+		} else if d <= 0 {
+			problems = append(problems, fmt.Sprintf("timeout %q must be positive", pc.Timeout))
+		}
+	}
+
+	names := make([]string, 0, len(pc.BaseURLs))
+	for name := range pc.BaseURLs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if canonicalService(name) == "" {
+			problems = append(problems, fmt.Sprintf("base_urls: unknown service %q, valid services are %s", name, strings.Join(services, ", ")))
+			continue
+		}
+		u, err := url.Parse(pc.BaseURLs[name])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("base_urls.%s: %q is not an http(s) URL", name, pc.BaseURLs[name]))
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, k := range pc.Keys {
+		if k.Name == "" || k.Key == "" {
+			problems = append(problems, "keys: every key needs a name and a key")
+		}
+		if seen[k.Name] {
+			problems = append(problems, fmt.Sprintf("keys: duplicate key name %q", k.Name))
+		}
+		seen[k.Name] = true
+	}
+	switch pc.KeySelection {
+	case "", "round_robin", "priority":
+	default:
+		problems = append(problems, fmt.Sprintf("key_selection %q must be round_robin or priority", pc.KeySelection))
+	}
+	return problems
+}
+
+// canonicalService returns the Service name matching name regardless of its
+// case and underscores, or "" if there is none.
+func canonicalService(name string) string {
+	normalized := strings.ToLower(strings.Replace(name, "_", "", -1))
+	for _, s := range services {
+		if strings.ToLower(s) == normalized {
+			return s
+		}
+	}
+	return ""
+}
+
+func parseJSONConfig(data []byte) (string, map[string]*ProfileConfig, error) {
+	file := struct {
+		DefaultProfile string                     `json:"default_profile"`
+		Profiles       map[string]json.RawMessage `json:"profiles"`
+	}{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return "", nil, err
+	}
+
+	profiles := map[string]*ProfileConfig{}
+	for name, raw := range file.Profiles {
+		pc := &ProfileConfig{}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(pc); err != nil {
+			return "", nil, fmt.Errorf("profile %q: %v", name, err)
+		}
+		profiles[name] = pc
+	}
+	return file.DefaultProfile, profiles, nil
+}
+
+func parseTOMLConfig(data []byte) (string, map[string]*ProfileConfig, error) {
+	var defaultProfile, section string
+	profiles := map[string]*ProfileConfig{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return "", nil, fmt.Errorf("line %d: unterminated section %q", n, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			profile := section
+			if i := strings.Index(section, "."); i >= 0 {
+				profile = section[:i]
+			}
+			if _, ok := profiles[profile]; !ok {
+				profiles[profile] = &ProfileConfig{}
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return "", nil, fmt.Errorf("line %d: expected key = value, got %q", n, line)
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseTOMLValue(strings.TrimSpace(value))
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %v", n, err)
+		}
+
+		if section == "" {
+			if key != "default_profile" {
+				return "", nil, fmt.Errorf("line %d: unknown setting %q outside of a profile", n, key)
+			}
+			defaultProfile = value
+			continue
+		}
+
+		// [staging.base_urls] sections hold the keys of a nested table
+		profile := section
+		if i := strings.Index(section, "."); i >= 0 {
+			profile, key = section[:i], section[i+1:]+"."+key
+		}
+		if err := profiles[profile].set(key, value); err != nil {
+			return "", nil, fmt.Errorf("line %d: %v", n, err)
+		}
+	}
+	return defaultProfile, profiles, scanner.Err()
+}
+
+// parseTOMLValue returns a string value, quoted or not, without any trailing
+// comment. Quoted strings may hold the \" and \\ escapes, and the other
+// escapes of TOML basic strings such as \n, \t or \u00e9.
+func parseTOMLValue(v string) (string, error) {
+	if strings.HasPrefix(v, `"`) {
+		end := 1
+		for ; end < len(v) && v[end] != '"'; end++ {
+			if v[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(v) {
+			return "", fmt.Errorf("unterminated string %s", v)
+		}
+		if rest := strings.TrimSpace(v[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("unexpected %q after string", rest)
+		}
+		s, err := strconv.Unquote(v[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", v[:end+1])
+		}
+		return s, nil
+	}
+	if i := strings.Index(v, "#"); i >= 0 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v), nil
+}
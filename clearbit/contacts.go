@@ -0,0 +1,179 @@
+package clearbit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// The stages of FindContacts
+const (
+	StageDomain     = "domain"
+	StageCompany    = "company"
+	StageProspector = "prospector"
+)
+
+// StageError is the error of one stage of FindContacts
+type StageError struct {
+	// Stage is StageDomain, StageCompany or StageProspector
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("clearbit: %s stage: %s", e.Stage, strings.TrimPrefix(e.Err.Error(), "clearbit: "))
+}
+
+// Unwrap returns the error of the stage
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// ContactQuery selects the contacts returned by FindContacts
+type ContactQuery struct {
+	Roles       []Role
+	SubRoles    []SubRole
+	Seniorities []Seniority
+	Titles      []string
+	Countries   []string
+	// Limit caps the number of contacts, MaxProspectorPageSize by default.
+	// Every page of MaxProspectorPageSize contacts is a Prospector call.
+	Limit int
+	// SkipCompany skips the enrichment of the company, saving a call
+	SkipCompany bool
+}
+
+// Account is the company and the contacts found by FindContacts
+type Account struct {
+	// Query is the company name or domain given to FindContacts
+	Query  string
+	Domain string
+	// Company is nil when the company was not enriched
+	Company  *Company
+	Contacts []Prospect
+	// Errors lists the errors of the stages that failed, in order
+	Errors []*StageError
+}
+
+// FindContacts finds the decision-makers of a company and their emails. It
+// resolves the domain of the company with the NameToDomain API, unless given
+// a URL or a domain with a known top level domain, enriches it with the Company API and searches its contacts
+// matching q with the Prospector API.
+//
+// A failed enrichment is recorded in the Errors of the account and does not
+// stop the search. The failure of the other stages is recorded too and
+// returned, along with what was found so far.
+func (c *Client) FindContacts(ctx context.Context, companyNameOrDomain string, q ContactQuery, opts ...CallOption) (*Account, error) {
+	opts = append([]CallOption{WithContext(ctx)}, opts...)
+	account := &Account{Query: companyNameOrDomain}
+	fail := func(stage string, err error) error {
+		serr := &StageError{Stage: stage, Err: err}
+		account.Errors = append(account.Errors, serr)
+		return serr
+	}
+
+	if domain, ok := asDomain(companyNameOrDomain); ok {
+		account.Domain = domain
+	} else {
+		result, _, err := c.NameToDomain.Find(NameToDomainFindParams{Name: companyNameOrDomain}, opts...)
+		if err != nil {
+			return account, fail(StageDomain, err)
+		}
+		account.Domain = result.Domain
+	}
+
+	if !q.SkipCompany {
+		company, _, err := c.Company.Find(CompanyFindParams{Domain: account.Domain}, opts...)
+		if err != nil {
+			_ = fail(StageCompany, err)
+		} else {
+			account.Company = company
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return account, fail(StageProspector, err)
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = MaxProspectorPageSize
+	}
+	pageSize := limit
+	if pageSize > MaxProspectorPageSize {
+		pageSize = MaxProspectorPageSize
+	}
+	for page := 1; len(account.Contacts) < limit; page++ {
+		results, _, err := c.Prospector.Search(ProspectorSearchParams{
+			Domain:      account.Domain,
+			Roles:       q.Roles,
+			SubRoles:    q.SubRoles,
+			Seniorities: q.Seniorities,
+			Titles:      q.Titles,
+			Countries:   q.Countries,
+			Page:        page,
+			PageSize:    pageSize,
+		}, opts...)
+		if err != nil {
+			return account, fail(StageProspector, err)
+		}
+		account.Contacts = append(account.Contacts, results.Results...)
+		if len(results.Results) < pageSize || page*pageSize >= results.Total {
+			break
+		}
+	}
+	if len(account.Contacts) > limit {
+		account.Contacts = account.Contacts[:limit]
+	}
+	return account, nil
+}
+
+// asDomain returns the domain of s if it is a URL, or a domain ending with a
+// known top level domain, rather than a company name such as "J.Crew".
+func asDomain(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if strings.ContainsAny(s, " \t") {
+		return "", false
+	}
+	scheme := strings.Contains(s, "://")
+	if scheme {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", false
+		}
+		s = u.Hostname()
+	}
+	host := strings.TrimPrefix(strings.ToLower(strings.SplitN(s, "/", 2)[0]), "www.")
+
+	dot := strings.LastIndex(host, ".")
+	if dot <= 0 || dot == len(host)-1 {
+		return "", false
+	}
+	if !scheme && !knownTLD(host[dot+1:]) {
+		return "", false
+	}
+	return host, true
+}
+
+// genericTLDs are the generic top level domains recognized by asDomain, on
+// top of the two letter country codes
+var genericTLDs = map[string]bool{
+	"com": true, "net": true, "org": true, "edu": true, "gov": true,
+	"mil": true, "int": true, "biz": true, "info": true, "io": true,
+	"ai": true, "app": true, "dev": true, "tech": true, "xyz": true,
+	"online": true, "site": true, "store": true, "shop": true,
+	"cloud": true, "inc": true, "ltd": true, "llc": true, "group": true,
+	"agency": true, "studio": true, "solutions": true, "digital": true,
+	"company": true, "global": true, "network": true, "systems": true,
+	"software": true, "media": true, "health": true, "finance": true,
+	"capital": true, "ventures": true, "partners": true, "services": true,
+}
+
+// knownTLD returns true for the generic top level domains and the two letter
+// country codes
+func knownTLD(tld string) bool {
+	if genericTLDs[tld] {
+		return true
+	}
+	return len(tld) == 2 && tld[0] >= 'a' && tld[0] <= 'z' && tld[1] >= 'a' && tld[1] <= 'z'
+}
@@ -1,6 +1,7 @@
 package clearbit
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -9,11 +10,72 @@ import (
 // DiscoverySearchParams wraps the parameters needed to interact with the
 // Discovery API through the Search method
 type DiscoverySearchParams struct {
-	Page     int    `url:"page,omitempty"`
-	PageSize int    `url:"page_size,omitempty"`
-	Limit    int    `url:"limit,omitempty"`
-	Sort     int    `url:"sort,omitempty"`
-	Query    string `url:"query,omitempty"`
+	Page int `url:"page,omitempty"`
+	// PageSize is the number of companies per page, up to
+	// MaxDiscoveryPageSize
+	PageSize int `url:"page_size,omitempty"`
+	// Limit caps the total number of companies returned over all pages
+	Limit int           `url:"limit,omitempty"`
+	Sort  DiscoverySort `url:"sort,omitempty"`
+	Query string        `url:"query,omitempty"`
+}
+
+// MaxDiscoveryPageSize is the largest page size accepted by the Discovery API
+const MaxDiscoveryPageSize = 100
+
+// DiscoverySort is the order of the companies returned by the Discovery API
+type DiscoverySort string
+
+// The sort orders of the Discovery API. The results are sorted by
+// DiscoverySortScore, their relevance to the query, by default.
+const (
+	DiscoverySortScore                = DiscoverySort("score")
+	DiscoverySortAlexaAsc             = DiscoverySort("alexa_asc")
+	DiscoverySortAlexaDesc            = DiscoverySort("alexa_desc")
+	DiscoverySortEmployeesAsc         = DiscoverySort("employees_asc")
+	DiscoverySortEmployeesDesc        = DiscoverySort("employees_desc")
+	DiscoverySortRaisedAsc            = DiscoverySort("raised_asc")
+	DiscoverySortRaisedDesc           = DiscoverySort("raised_desc")
+	DiscoverySortMarketCapAsc         = DiscoverySort("market_cap_asc")
+	DiscoverySortMarketCapDesc        = DiscoverySort("market_cap_desc")
+	DiscoverySortTwitterFollowersAsc  = DiscoverySort("twitter_followers_asc")
+	DiscoverySortTwitterFollowersDesc = DiscoverySort("twitter_followers_desc")
+)
+
+// DiscoverySorts returns the sort orders supported by the Discovery API
+func DiscoverySorts() []DiscoverySort {
+	return append([]DiscoverySort(nil), discoverySorts...)
+}
+
+var discoverySorts = []DiscoverySort{
+	DiscoverySortScore,
+	DiscoverySortAlexaAsc, DiscoverySortAlexaDesc,
+	DiscoverySortEmployeesAsc, DiscoverySortEmployeesDesc,
+	DiscoverySortRaisedAsc, DiscoverySortRaisedDesc,
+	DiscoverySortMarketCapAsc, DiscoverySortMarketCapDesc,
+	DiscoverySortTwitterFollowersAsc, DiscoverySortTwitterFollowersDesc,
+}
+
+// Validate checks the params before they are sent
+func (p DiscoverySearchParams) Validate() error {
+	if err := required("query", p.Query); err != nil {
+		return err
+	}
+	switch {
+	case p.Page < 0:
+		return &ValidationError{Field: "page", Message: "must not be negative"}
+	case p.PageSize < 0 || p.PageSize > MaxDiscoveryPageSize:
+		return &ValidationError{Field: "page_size", Message: fmt.Sprintf("must be between 1 and %d", MaxDiscoveryPageSize)}
+	case p.Limit < 0:
+		return &ValidationError{Field: "limit", Message: "must not be negative"}
+	}
+	if p.Sort == "" {
+		return nil
+	}
+	if contains(discoverySorts, p.Sort) {
+		return nil
+	}
+	return &ValidationError{Field: "sort", Message: fmt.Sprintf("unknown sort order %q", p.Sort)}
 }
 
 // DiscoveryResults represents each page of companies returned by a call to
@@ -30,23 +92,25 @@ type DiscoveryResults struct {
 // example, you could search for all companies with a specific funding, that
 // use a certain technology, or that are similar to your existing customers.
 type DiscoveryService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newDiscoveryService(sling *sling.Sling, baseURL string) *DiscoveryService {
+func newDiscoveryService(sling *sling.Sling, baseURL string, d *dispatcher) *DiscoveryService {
 	return &DiscoveryService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/companies/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/companies/"),
+		dispatcher: d,
 	}
 }
 
 // Search lets you search for companies via specific criteria. For example, you
 // could search for all companies with a specific funding, that use a certain
 // technology, or that are similar to your existing customers.
-func (s *DiscoveryService) Search(params DiscoverySearchParams) (*DiscoveryResults, *http.Response, error) {
+func (s *DiscoveryService) Search(params DiscoverySearchParams, opts ...CallOption) (*DiscoveryResults, *http.Response, error) {
 	item := new(DiscoveryResults)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("search").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceDiscovery, Operation: "search", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("search").QueryStruct(params), opts)
+	return item, resp, err
 }
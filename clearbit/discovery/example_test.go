@@ -0,0 +1,176 @@
+package discovery_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+	"github.com/clearbit/clearbit-go/clearbit/discovery"
+)
+
+func ExampleQuery_output() {
+	q := discovery.Tech("stripe").And(
+		discovery.EmployeesRange(50, 200),
+		discovery.Or(discovery.Location("San Francisco"), discovery.Country("CA")),
+		discovery.Not(discovery.Type("nonprofit")),
+	)
+	fmt.Println(q)
+
+	// Output: tech:stripe employees:50~200 or:(location:"San Francisco" country:CA) not:(type:nonprofit)
+}
+
+func ExampleParse_output() {
+	q, err := discovery.Parse(`tech:stripe or:(raised:1000000~ and:(employees:~50 tag:b2b)) not:type:nonprofit`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(q.Pretty())
+
+	_, err = discovery.Parse(`tech:stripe employee:50~200`)
+	fmt.Println(err)
+
+	_, err = discovery.Parse(`raised:lots`)
+	fmt.Println(err)
+
+	// Output:
+	// tech:stripe
+	// or:(
+	//   raised:1000000~
+	//   and:(
+	//     employees:~50
+	//     tag:b2b
+	//   )
+	// )
+	// not:(
+	//   type:nonprofit
+	// )
+	// discovery: unknown field "employee" at offset 12
+	// discovery: raised takes a range such as 10~50, not "lots" at offset 0
+}
+
+func ExampleMatcher_output() {
+	var stripe, acme, initech clearbit.Company
+	stripe.Domain, stripe.Tech, stripe.Metrics.Employees = "stripe.com", []string{"stripe"}, 5000
+	acme.Domain, acme.Tech, acme.Metrics.Employees = "acme.com", []string{"stripe", "segment"}, 120
+	initech.Domain, initech.Tech = "initech.com", []string{"Stripe"}
+	initech.Geo.CountryCode = "CA"
+
+	m, err := discovery.CompileString(`tech:stripe or:(employees:50~200 country:ca)`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range m.Filter([]clearbit.Company{stripe, acme, initech}) {
+		fmt.Println(c.Domain)
+	}
+
+	_, err = discovery.CompileString(`similar:stripe.com`)
+	fmt.Println(err)
+
+	// Output:
+	// acme.com
+	// initech.com
+	// discovery: similar cannot be evaluated locally
+}
+
+func ExampleSavedSearches_output() {
+	dir, _ := ioutil.TempDir("", "searches")
+	defer os.RemoveAll(dir)
+
+	query := discovery.Tech("stripe").And(discovery.Country("CA"))
+	searcher := &clearbit.FakeDiscoverySearcher{
+		Results: map[string]*clearbit.DiscoveryResults{
+			query.String(): {Total: 2, Results: []clearbit.Company{{Domain: "acme.ca"}, {Domain: "initech.ca"}}},
+		},
+	}
+
+	searches, _ := discovery.NewSavedSearches(searcher, discovery.WithSearchFile(filepath.Join(dir, "searches.json")))
+	_ = searches.Add("stripe-canada", query)
+
+	delta, _ := searches.Run("stripe-canada")
+	fmt.Println(delta.FirstRun, len(delta.Added), delta.Total)
+
+	// a new company starts using Stripe while another one stops
+	searcher.Results[query.String()].Results = []clearbit.Company{{Domain: "initech.ca"}, {Domain: "hooli.ca"}}
+
+	// the seen domains are read back from the search file
+	searches, _ = discovery.NewSavedSearches(searcher, discovery.WithSearchFile(filepath.Join(dir, "searches.json")))
+	delta, _ = searches.Run("stripe-canada")
+	for _, c := range delta.Added {
+		fmt.Println("added", c.Domain)
+	}
+	fmt.Println("removed", delta.Removed)
+
+	// Output:
+	// true 2 2
+	// added hooli.ca
+	// removed [acme.ca]
+}
+
+func ExampleSavedSearches_truncated_output() {
+	// the query matches 150 companies but only the first page of 100 is
+	// fetched, and the API returns them in a different order every time
+	page := func(from int) *clearbit.DiscoveryResults {
+		results := &clearbit.DiscoveryResults{Total: 150}
+		for i := 0; i < clearbit.MaxDiscoveryPageSize; i++ {
+			domain := fmt.Sprintf("company%d.com", (from+i)%150)
+			results.Results = append(results.Results, clearbit.Company{Domain: domain})
+		}
+		return results
+	}
+	query := discovery.Tech("stripe")
+	searcher := &clearbit.FakeDiscoverySearcher{
+		Results: map[string]*clearbit.DiscoveryResults{query.String(): page(0)},
+	}
+
+	searches, _ := discovery.NewSavedSearches(searcher, discovery.WithMaxPages(1))
+	_ = searches.Add("stripe", query)
+	_, _ = searches.Run("stripe")
+
+	searcher.Results[query.String()] = page(50)
+	delta, _ := searches.Run("stripe")
+	fmt.Println(delta.Truncated, len(delta.Added), len(delta.Removed))
+
+	// Output: true 50 0
+}
+
+func ExampleSavedSearches_concurrent_output() {
+	dir, _ := ioutil.TempDir("", "searches")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "searches.json")
+
+	searcher := &clearbit.FakeDiscoverySearcher{Results: map[string]*clearbit.DiscoveryResults{}}
+	searches, _ := discovery.NewSavedSearches(searcher, discovery.WithSearchFile(path))
+	for i := 0; i < 10; i++ {
+		query := discovery.Tech(fmt.Sprintf("tech%d", i))
+		searcher.Results[query.String()] = &clearbit.DiscoveryResults{
+			Total:   1,
+			Results: []clearbit.Company{{Domain: fmt.Sprintf("company%d.com", i)}},
+		}
+		_ = searches.Add(fmt.Sprintf("search%d", i), query)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, _ = searches.Run(name)
+		}(fmt.Sprintf("search%d", i))
+	}
+	wg.Wait()
+
+	// every run is in the search file, whatever order the saves ran in
+	searches, _ = discovery.NewSavedSearches(searcher, discovery.WithSearchFile(path))
+	seen := 0
+	for _, saved := range searches.List() {
+		seen += len(saved.Seen)
+	}
+	fmt.Println(seen)
+
+	// Output: 10
+}
@@ -0,0 +1,71 @@
+package discovery
+
+import "github.com/clearbit/clearbit-go/clearbit"
+
+// Kind is the kind of values a field accepts
+type Kind int
+
+const (
+	// Text fields take a word or a quoted phrase, e.g. location:"San Francisco"
+	Text Kind = iota
+	// Range fields take numeric bounds, e.g. employees:50~200, raised:1000000~
+	// or alexa_rank:~1000
+	Range
+)
+
+// Field is a field of the Discovery query language
+type Field struct {
+	Name string
+	Kind Kind
+
+	// text returns the values of a Text field compared to the query value,
+	// contains telling whether they must contain it rather than equal it
+	text     func(c *clearbit.Company) []string
+	contains bool
+	// number returns the value of a Range field, 0 when unknown
+	number func(c *clearbit.Company) int
+}
+
+// Fields lists the fields supported by the Discovery API.
+// https://dashboard.clearbit.com/docs#discovery-api
+var Fields = []Field{
+	{Name: "name", Kind: Text, contains: true, text: func(c *clearbit.Company) []string { return []string{c.Name, c.LegalName} }},
+	{Name: "domain", Kind: Text, text: func(c *clearbit.Company) []string { return append([]string{c.Domain}, c.DomainAliases...) }},
+	{Name: "description", Kind: Text, contains: true, text: func(c *clearbit.Company) []string { return []string{c.Description} }},
+	{Name: "location", Kind: Text, contains: true, text: func(c *clearbit.Company) []string { return []string{c.Location} }},
+	{Name: "city", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Geo.City} }},
+	{Name: "state", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Geo.State, c.Geo.StateCode} }},
+	{Name: "country", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Geo.Country, c.Geo.CountryCode} }},
+	{Name: "postal_code", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Geo.PostalCode} }},
+	{Name: "tech", Kind: Text, text: func(c *clearbit.Company) []string { return c.Tech }},
+	{Name: "tag", Kind: Text, text: func(c *clearbit.Company) []string { return c.Tags }},
+	{Name: "type", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Type} }},
+	{Name: "ticker", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Ticker} }},
+	{Name: "sector", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Category.Sector} }},
+	{Name: "industry_group", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Category.IndustryGroup} }},
+	{Name: "industry", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Category.Industry} }},
+	{Name: "sub_industry", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Category.SubIndustry} }},
+	// similarity is computed by the API and cannot be evaluated locally
+	{Name: "similar", Kind: Text},
+	{Name: "crunchbase", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Crunchbase.Handle} }},
+	{Name: "facebook", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Facebook.Handle} }},
+	{Name: "linkedin", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.LinkedIn.Handle} }},
+	{Name: "twitter", Kind: Text, text: func(c *clearbit.Company) []string { return []string{c.Twitter.Handle} }},
+	{Name: "employees", Kind: Range, number: func(c *clearbit.Company) int { return c.Metrics.Employees }},
+	{Name: "raised", Kind: Range, number: func(c *clearbit.Company) int { return c.Metrics.Raised }},
+	{Name: "market_cap", Kind: Range, number: func(c *clearbit.Company) int { return c.Metrics.MarketCap }},
+	{Name: "alexa_rank", Kind: Range, number: func(c *clearbit.Company) int { return c.Metrics.AlexaGlobalRank }},
+	{Name: "alexa_us_rank", Kind: Range, number: func(c *clearbit.Company) int { return c.Metrics.AlexaUsRank }},
+	{Name: "twitter_followers", Kind: Range, number: func(c *clearbit.Company) int { return c.Twitter.Followers }},
+	{Name: "twitter_following", Kind: Range, number: func(c *clearbit.Company) int { return c.Twitter.Following }},
+}
+
+// LookupField returns the field of the given name
+func LookupField(name string) (Field, bool) {
+	for _, f := range Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
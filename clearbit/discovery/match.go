@@ -0,0 +1,143 @@
+package discovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+)
+
+// Matcher evaluates a Discovery query against Company records, e.g. a local
+// store of enriched companies, without calling the API.
+//
+// Text fields are compared case insensitively. The name, description and
+// location fields match the companies containing the value, the other ones
+// the companies having a value equal to it. Range fields never match a
+// company whose value is 0, which the Company type uses for unknown values.
+type Matcher struct {
+	query Query
+	match func(c *clearbit.Company) bool
+}
+
+// Compile validates q and returns its Matcher. Queries on the similar field
+// cannot be evaluated locally and are rejected.
+func Compile(q Query) (*Matcher, error) {
+	if err := Validate(q); err != nil {
+		return nil, err
+	}
+	match, err := compile(q)
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{query: q, match: match}, nil
+}
+
+// CompileString parses the query string s and returns its Matcher
+func CompileString(s string) (*Matcher, error) {
+	q, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(q)
+}
+
+// Query returns the query evaluated by m
+func (m *Matcher) Query() Query {
+	return m.query
+}
+
+// Match returns true if c matches the query
+func (m *Matcher) Match(c *clearbit.Company) bool {
+	return m.match(c)
+}
+
+// Filter returns the companies matching the query
+func (m *Matcher) Filter(companies []clearbit.Company) []clearbit.Company {
+	var matching []clearbit.Company
+	for i := range companies {
+		if m.match(&companies[i]) {
+			matching = append(matching, companies[i])
+		}
+	}
+	return matching
+}
+
+func compile(q Query) (func(c *clearbit.Company) bool, error) {
+	if q.Operator == "" {
+		return compileTerm(q.Field, q.Value)
+	}
+
+	clauses := make([]func(c *clearbit.Company) bool, len(q.Clauses))
+	for i, clause := range q.Clauses {
+		match, err := compile(clause)
+		if err != nil {
+			return nil, err
+		}
+		clauses[i] = match
+	}
+
+	switch q.Operator {
+	case OpOr:
+		return func(c *clearbit.Company) bool {
+			for _, match := range clauses {
+				if match(c) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case OpNot:
+		return func(c *clearbit.Company) bool {
+			return !clauses[0](c)
+		}, nil
+	}
+	return func(c *clearbit.Company) bool {
+		for _, match := range clauses {
+			if !match(c) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func compileTerm(field, value string) (func(c *clearbit.Company) bool, error) {
+	f, _ := LookupField(field)
+
+	if f.Kind == Range {
+		min, max := parseRange(value)
+		return func(c *clearbit.Company) bool {
+			n := f.number(c)
+			return n != 0 && n >= min && n <= max
+		}, nil
+	}
+
+	if f.text == nil {
+		return nil, &SyntaxError{Offset: -1, Msg: fmt.Sprintf("%s cannot be evaluated locally", field)}
+	}
+	value = strings.ToLower(value)
+	return func(c *clearbit.Company) bool {
+		for _, v := range f.text(c) {
+			v = strings.ToLower(v)
+			if v == value || (f.contains && strings.Contains(v, value)) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// parseRange returns the bounds of a validated range value
+func parseRange(value string) (min, max int) {
+	lo, hi, isRange := strings.Cut(value, "~")
+	if !isRange {
+		hi = lo
+	}
+	min, _ = strconv.Atoi(lo)
+	max = int(^uint(0) >> 1)
+	if hi != "" {
+		max, _ = strconv.Atoi(hi)
+	}
+	return min, max
+}
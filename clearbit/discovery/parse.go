@@ -0,0 +1,202 @@
+package discovery
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SyntaxError reports an invalid Discovery query
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the query, or -1 for a query
+	// that was not parsed
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Offset < 0 {
+		return "discovery: " + e.Msg
+	}
+	return fmt.Sprintf("discovery: %s at offset %d", e.Msg, e.Offset)
+}
+
+// rangeValue matches the values of Range fields: 50~200, 50~, ~200 or 50
+var rangeValue = regexp.MustCompile(`^(\d+~\d*|~\d+|\d+)$`)
+
+// Parse parses and validates a Discovery query string
+func Parse(s string) (Query, error) {
+	p := &parser{s: s}
+	clauses, err := p.clauses()
+	if err != nil {
+		return Query{}, err
+	}
+	if p.pos < len(s) {
+		return Query{}, p.errorf("unexpected %q", s[p.pos])
+	}
+	if len(clauses) == 0 {
+		return Query{}, &SyntaxError{Offset: 0, Msg: "empty query"}
+	}
+	return And(clauses...), nil
+}
+
+// Validate checks the fields and values of a query built by hand
+func Validate(q Query) error {
+	switch q.Operator {
+	case "":
+		return checkTerm(q.Field, q.Value, -1)
+	case OpAnd, OpOr:
+		if len(q.Clauses) == 0 {
+			return &SyntaxError{Offset: -1, Msg: q.Operator + " without clauses"}
+		}
+	case OpNot:
+		if len(q.Clauses) != 1 {
+			return &SyntaxError{Offset: -1, Msg: "not takes a single clause"}
+		}
+	default:
+		return &SyntaxError{Offset: -1, Msg: fmt.Sprintf("unknown operator %q", q.Operator)}
+	}
+	for _, c := range q.Clauses {
+		if err := Validate(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTerm validates a term found at offset
+func checkTerm(field, value string, offset int) error {
+	f, ok := LookupField(field)
+	if !ok {
+		return &SyntaxError{Offset: offset, Msg: fmt.Sprintf("unknown field %q", field)}
+	}
+	if value == "" {
+		return &SyntaxError{Offset: offset, Msg: fmt.Sprintf("missing value for %s", field)}
+	}
+	if f.Kind == Range && !rangeValue.MatchString(value) {
+		return &SyntaxError{Offset: offset, Msg: fmt.Sprintf("%s takes a range such as 10~50, not %q", field, value)}
+	}
+	return nil
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// clauses parses clauses up to the end of the query or a closing parenthesis
+func (p *parser) clauses() ([]Query, error) {
+	var clauses []Query
+	for {
+		p.skipSpaces()
+		if p.pos == len(p.s) || p.s[p.pos] == ')' {
+			return clauses, nil
+		}
+		q, err := p.clause()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, q)
+	}
+}
+
+func (p *parser) clause() (Query, error) {
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ':' && !strings.ContainsRune(" \t\r\n()\"", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if name == "" {
+		return Query{}, p.errorf("expected a field name")
+	}
+	if p.pos == len(p.s) || p.s[p.pos] != ':' {
+		return Query{}, &SyntaxError{Offset: start, Msg: fmt.Sprintf("expected field:value, got %q", name)}
+	}
+	p.pos++
+
+	switch name {
+	case OpAnd, OpOr, OpNot:
+		return p.operator(name, start)
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return Query{}, err
+	}
+	if err := checkTerm(name, value, start); err != nil {
+		return Query{}, err
+	}
+	return Term(name, value), nil
+}
+
+// operator parses the clauses of an operator, either a parenthesized list or,
+// for not, a single clause
+func (p *parser) operator(op string, start int) (Query, error) {
+	if p.pos == len(p.s) || p.s[p.pos] != '(' {
+		if op != OpNot {
+			return Query{}, p.errorf("expected ( after %s:", op)
+		}
+		q, err := p.clause()
+		if err != nil {
+			return Query{}, err
+		}
+		return Not(q), nil
+	}
+	p.pos++
+
+	clauses, err := p.clauses()
+	if err != nil {
+		return Query{}, err
+	}
+	if p.pos == len(p.s) {
+		return Query{}, &SyntaxError{Offset: start, Msg: "unclosed " + op + ":("}
+	}
+	p.pos++
+
+	switch {
+	case len(clauses) == 0:
+		return Query{}, &SyntaxError{Offset: start, Msg: op + " without clauses"}
+	case op == OpNot:
+		return Not(And(clauses...)), nil
+	}
+	return Query{Operator: op, Clauses: clauses}, nil
+}
+
+// value parses a bare or quoted value
+func (p *parser) value() (string, error) {
+	if p.pos < len(p.s) && p.s[p.pos] == '"' {
+		start := p.pos
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.s) {
+			c := p.s[p.pos]
+			p.pos++
+			switch {
+			case c == '"':
+				return b.String(), nil
+			case c == '\\' && p.pos < len(p.s):
+				b.WriteByte(p.s[p.pos])
+				p.pos++
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", &SyntaxError{Offset: start, Msg: "unterminated quoted value"}
+	}
+
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(" \t\r\n()", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
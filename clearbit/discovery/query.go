@@ -0,0 +1,216 @@
+/*
+Package discovery builds, parses and checks queries of the Clearbit Discovery
+API, so mistakes are caught before a call is spent.
+
+Queries are built from the field helpers and combined with And, Or and Not:
+
+	q := discovery.Tech("stripe").And(
+		discovery.EmployeesRange(50, 200),
+		discovery.Or(discovery.Country("US"), discovery.Country("CA")),
+	)
+	results, resp, err := client.Discovery.Search(clearbit.DiscoverySearchParams{
+		Query: q.String(),
+	})
+
+and existing query strings are validated with Parse.
+
+A Matcher evaluates the same queries against Company records held locally,
+so saved segments can be applied offline, and SavedSearches re-runs named
+queries against the API to report the companies that started or stopped
+matching them.
+*/
+package discovery
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Operators combining queries
+const (
+	OpAnd = "and"
+	OpOr  = "or"
+	OpNot = "not"
+)
+
+// Query is a node of a Discovery query: either a term matching a field
+// against a value, or an operator combining other queries.
+type Query struct {
+	// Operator is OpAnd, OpOr or OpNot, or empty for a term
+	Operator string
+	// Clauses are the queries combined by Operator
+	Clauses []Query
+	// Field and Value are the field and the raw value of a term
+	Field string
+	Value string
+}
+
+// Term returns a query matching field against value
+func Term(field, value string) Query {
+	return Query{Field: field, Value: value}
+}
+
+// RangeTerm returns a query matching a range field between min and max. A
+// max of 0 or less leaves the range open ended.
+func RangeTerm(field string, min, max int) Query {
+	value := strconv.Itoa(min) + "~"
+	if max > 0 {
+		value += strconv.Itoa(max)
+	}
+	return Term(field, value)
+}
+
+// Name matches the name of the companies
+func Name(name string) Query { return Term("name", name) }
+
+// Domain matches the domain of the companies
+func Domain(domain string) Query { return Term("domain", domain) }
+
+// Description matches the description of the companies
+func Description(text string) Query { return Term("description", text) }
+
+// Location matches the location of the companies
+func Location(location string) Query { return Term("location", location) }
+
+// City matches the city of the companies
+func City(city string) Query { return Term("city", city) }
+
+// State matches the state of the companies
+func State(state string) Query { return Term("state", state) }
+
+// Country matches the country of the companies
+func Country(country string) Query { return Term("country", country) }
+
+// Tech matches the companies using a technology, e.g. "stripe"
+func Tech(tech string) Query { return Term("tech", tech) }
+
+// Tag matches the tags of the companies
+func Tag(tag string) Query { return Term("tag", tag) }
+
+// Type matches the type of the companies, e.g. "private"
+func Type(typ string) Query { return Term("type", typ) }
+
+// Sector matches the sector of the companies
+func Sector(sector string) Query { return Term("sector", sector) }
+
+// Industry matches the industry of the companies
+func Industry(industry string) Query { return Term("industry", industry) }
+
+// Similar matches the companies similar to the one of the given domain
+func Similar(domain string) Query { return Term("similar", domain) }
+
+// EmployeesRange matches the companies employing between min and max people
+func EmployeesRange(min, max int) Query { return RangeTerm("employees", min, max) }
+
+// RaisedRange matches the companies that raised between min and max dollars
+func RaisedRange(min, max int) Query { return RangeTerm("raised", min, max) }
+
+// MarketCapRange matches the companies valued between min and max dollars
+func MarketCapRange(min, max int) Query { return RangeTerm("market_cap", min, max) }
+
+// AlexaRankRange matches the companies whose global Alexa rank is between min
+// and max
+func AlexaRankRange(min, max int) Query { return RangeTerm("alexa_rank", min, max) }
+
+// TwitterFollowersRange matches the companies followed by between min and max
+// Twitter accounts
+func TwitterFollowersRange(min, max int) Query { return RangeTerm("twitter_followers", min, max) }
+
+// And matches the companies matching every query
+func And(queries ...Query) Query { return combine(OpAnd, queries) }
+
+// Or matches the companies matching any of the queries
+func Or(queries ...Query) Query { return combine(OpOr, queries) }
+
+// Not matches the companies not matching q
+func Not(q Query) Query { return Query{Operator: OpNot, Clauses: []Query{q}} }
+
+// And matches the companies matching q and every other query
+func (q Query) And(others ...Query) Query { return And(append([]Query{q}, others...)...) }
+
+// Or matches the companies matching q or any of the other queries
+func (q Query) Or(others ...Query) Query { return Or(append([]Query{q}, others...)...) }
+
+// combine joins queries with op, flattening the queries already joined with
+// op.
+func combine(op string, queries []Query) Query {
+	var clauses []Query
+	for _, q := range queries {
+		if q.Operator == op {
+			clauses = append(clauses, q.Clauses...)
+		} else {
+			clauses = append(clauses, q)
+		}
+	}
+	if len(clauses) == 1 {
+		return clauses[0]
+	}
+	return Query{Operator: op, Clauses: clauses}
+}
+
+// String renders q in the Discovery query syntax. The clauses of a top level
+// and are separated by spaces, as the API joins them with and.
+func (q Query) String() string {
+	if q.Operator == OpAnd {
+		return join(q.Clauses, " ")
+	}
+	return q.render()
+}
+
+func (q Query) render() string {
+	if q.Operator == "" {
+		return q.Field + ":" + quote(q.Value)
+	}
+	return q.Operator + ":(" + join(q.shown(), " ") + ")"
+}
+
+// shown returns the clauses displayed between the parentheses of an
+// operator, the and inside a not being implicit.
+func (q Query) shown() []Query {
+	if q.Operator == OpNot && len(q.Clauses) == 1 && q.Clauses[0].Operator == OpAnd {
+		return q.Clauses[0].Clauses
+	}
+	return q.Clauses
+}
+
+// Pretty renders q over several lines, indenting the clauses of operators
+func (q Query) Pretty() string {
+	var b strings.Builder
+	if q.Operator == OpAnd {
+		for _, c := range q.Clauses {
+			c.pretty(&b, 0)
+		}
+	} else {
+		q.pretty(&b, 0)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (q Query) pretty(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	if q.Operator == "" {
+		b.WriteString(indent + q.render() + "\n")
+		return
+	}
+	b.WriteString(indent + q.Operator + ":(\n")
+	for _, c := range q.shown() {
+		c.pretty(b, depth+1)
+	}
+	b.WriteString(indent + ")\n")
+}
+
+func join(queries []Query, sep string) string {
+	parts := make([]string, len(queries))
+	for i, q := range queries {
+		parts[i] = q.render()
+	}
+	return strings.Join(parts, sep)
+}
+
+// quote quotes the values that would not be read back as a single value
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\"():") {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
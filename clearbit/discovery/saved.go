@@ -0,0 +1,247 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+	"github.com/clearbit/clearbit-go/clearbit/internal/statefile"
+)
+
+// SavedSearch is a named Discovery query and the domains of the companies it
+// matched at its last run
+type SavedSearch struct {
+	Name    string    `json:"name"`
+	Query   string    `json:"query"`
+	Seen    []string  `json:"seen"`
+	LastRun time.Time `json:"last_run"`
+}
+
+// Delta reports the changes of the results of a saved search since its
+// previous run
+type Delta struct {
+	Name string
+	// Added are the companies newly matching the query
+	Added []clearbit.Company
+	// Removed are the domains of the companies no longer matching the query
+	Removed []string
+	// Total is the number of companies fetched for the query
+	Total int
+	// Truncated is true when the query matched more companies than the
+	// pages fetched hold, see SavedSearches.Run
+	Truncated bool
+	// FirstRun is true when the search had never been run, in which case
+	// every company is reported in Added
+	FirstRun bool
+}
+
+// SavedSearches runs saved Discovery queries on demand and reports the
+// companies that started or stopped matching them since their previous run.
+type SavedSearches struct {
+	searcher clearbit.DiscoverySearcher
+	path     string
+	file     statefile.File
+	maxPages int
+
+	mu       sync.Mutex
+	searches map[string]*SavedSearch
+}
+
+// SavedSearchOption is an option passed to the NewSavedSearches function
+type SavedSearchOption func(*SavedSearches)
+
+// WithSearchFile persists the saved searches to path so they survive
+// restarts. The file is read by NewSavedSearches and written after every
+// change.
+func WithSearchFile(path string) SavedSearchOption {
+	return func(s *SavedSearches) {
+		s.path = path
+	}
+}
+
+// WithMaxPages caps the number of pages fetched by each run, 10 by default.
+// Every page is a Discovery call.
+func WithMaxPages(n int) SavedSearchOption {
+	return func(s *SavedSearches) {
+		s.maxPages = n
+	}
+}
+
+// NewSavedSearches returns the SavedSearches running their queries with
+// searcher, usually the Discovery field of a clearbit.Client.
+func NewSavedSearches(searcher clearbit.DiscoverySearcher, options ...SavedSearchOption) (*SavedSearches, error) {
+	s := &SavedSearches{
+		searcher: searcher,
+		maxPages: 10,
+		searches: map[string]*SavedSearch{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.maxPages < 1 {
+		s.maxPages = 1
+	}
+
+	if s.path != "" {
+		s.file.Path = s.path
+		data, err := ioutil.ReadFile(s.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &s.searches); err != nil {
+				return nil, fmt.Errorf("discovery: reading search file %s: %v", s.path, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Add saves q under name. Replacing the query of a saved search forgets the
+// companies it matched.
+func (s *SavedSearches) Add(name string, q Query) error {
+	if err := Validate(q); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if saved, ok := s.searches[name]; !ok || saved.Query != q.String() {
+		s.searches[name] = &SavedSearch{Name: name, Query: q.String()}
+	}
+	s.mu.Unlock()
+	return s.save()
+}
+
+// Remove deletes the saved search of the given name
+func (s *SavedSearches) Remove(name string) error {
+	s.mu.Lock()
+	delete(s.searches, name)
+	s.mu.Unlock()
+	return s.save()
+}
+
+// List returns the saved searches sorted by name
+func (s *SavedSearches) List() []SavedSearch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]SavedSearch, 0, len(s.searches))
+	for _, saved := range s.searches {
+		list = append(list, *saved)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Run searches the companies matching the saved search of the given name and
+// returns the changes since its previous run. The call options are given to
+// every Discovery call.
+//
+// When the query matches more companies than the pages fetched hold, the
+// delta is Truncated: the companies past the cutoff cannot be told apart from
+// the ones no longer matching, so Removed is left empty and the domains seen
+// before are kept.
+func (s *SavedSearches) Run(name string, opts ...clearbit.CallOption) (*Delta, error) {
+	s.mu.Lock()
+	saved, ok := s.searches[name]
+	var query string
+	if ok {
+		query = saved.Query
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("discovery: no saved search %q", name)
+	}
+
+	companies, truncated, err := s.search(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	// the search may have been replaced or removed while it was running
+	saved, ok = s.searches[name]
+	if !ok || saved.Query != query {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("discovery: saved search %q changed while running", name)
+	}
+
+	delta := &Delta{Name: name, FirstRun: saved.LastRun.IsZero(), Truncated: truncated}
+	seen := map[string]bool{}
+	for _, domain := range saved.Seen {
+		seen[domain] = true
+	}
+	var domains []string
+	for _, c := range companies {
+		domains = append(domains, c.Domain)
+		if !seen[c.Domain] {
+			delta.Added = append(delta.Added, c)
+		}
+		delete(seen, c.Domain)
+	}
+	delta.Total = len(domains)
+	for domain := range seen {
+		if truncated {
+			domains = append(domains, domain)
+		} else {
+			delta.Removed = append(delta.Removed, domain)
+		}
+	}
+	sort.Strings(delta.Removed)
+	sort.Strings(domains)
+
+	saved.Seen = domains
+	saved.LastRun = time.Now().UTC()
+	s.mu.Unlock()
+
+	return delta, s.save()
+}
+
+// search returns every company matching query, up to maxPages pages, once
+// per domain. truncated is true when more companies match than were fetched.
+func (s *SavedSearches) search(query string, opts []clearbit.CallOption) (companies []clearbit.Company, truncated bool, err error) {
+	found := map[string]bool{}
+	for page := 1; ; page++ {
+		results, _, err := s.searcher.Search(clearbit.DiscoverySearchParams{
+			Query:    query,
+			Page:     page,
+			PageSize: clearbit.MaxDiscoveryPageSize,
+		}, opts...)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, c := range results.Results {
+			if c.Domain == "" || found[c.Domain] {
+				continue
+			}
+			found[c.Domain] = true
+			companies = append(companies, c)
+		}
+
+		more := len(results.Results) == clearbit.MaxDiscoveryPageSize &&
+			page*clearbit.MaxDiscoveryPageSize < results.Total
+		if !more {
+			return companies, false, nil
+		}
+		if page == s.maxPages {
+			return companies, true, nil
+		}
+	}
+}
+
+// save writes the saved searches to the search file, if any
+func (s *SavedSearches) save() error {
+	if s.path == "" {
+		return nil
+	}
+	return s.file.Save(func() ([]byte, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return json.MarshalIndent(s.searches, "", "  ")
+	})
+}
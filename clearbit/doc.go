@@ -20,6 +20,11 @@ If you use the httpClient just to set the timeout you can instead use WithTimeou
 
 Both can be combined and the order is not important.
 
+The configuration can also be loaded from a file with named profiles, each
+setting being overridable through the CLEARBIT_* environment variables:
+
+  client, err := clearbit.NewClientFromConfig("clearbit.toml", "staging")
+
 Once the client is created you can use any of the Clearbit APIs
 
 	client.Autocomplete
@@ -50,6 +55,84 @@ Example:
       }
   }
 
+Every service method also accepts CallOptions changing a single call:
+
+  person, resp, err := client.Person.Find(
+      clearbit.PersonFindParams{Email: "alex@clearbit.com"},
+      clearbit.WithContext(ctx),
+      clearbit.WithCallTimeout(2*time.Second),
+  )
+
+Several API keys can be used with a KeyPool, calls failing because a key is
+invalid, out of credits or rate limited are retried with the next key:
+
+  pool := clearbit.NewKeyPool(clearbit.RoundRobin)
+  pool.Add("marketing", "sk_1234567890123123")
+  pool.Add("sales", "sk_3213210987654321")
+  client := clearbit.NewClient(clearbit.WithKeyPool(pool))
+
+Cross-cutting behavior such as logging or metrics can be added to every call
+with WithMiddleware:
+
+  client := clearbit.NewClient(clearbit.WithMiddleware(logCalls, countCalls))
+
+Calls can be logged through log/slog with WithLogger, personal data in the
+params and the API key are never written to the logs:
+
+  client := clearbit.NewClient(clearbit.WithLogger(slog.Default()))
+
+Request counts, latencies, error types and cache hits are collected per
+service with WithMetrics and can be served to Prometheus or through expvar:
+
+  metrics := clearbit.NewMetrics()
+  client := clearbit.NewClient(clearbit.WithMetrics(metrics))
+  http.Handle("/metrics", metrics)
+
+Calls can be traced by giving a Tracer to WithTracer. The span of each call
+is propagated to Clearbit through the W3C traceparent header.
+
+Billable calls can be capped per day and month with a Budget:
+
+  budget, err := clearbit.NewBudget(
+      clearbit.WithServiceLimits("person", clearbit.BudgetLimits{Daily: 1000}),
+      clearbit.WithBudgetFile("clearbit-budget.json"),
+  )
+  client := clearbit.NewClient(clearbit.WithBudget(budget))
+
+The requests a job would make can be inspected before running it for real
+with WithDryRun, nothing is sent and every call returns an ErrDryRun:
+
+  dryRun := &clearbit.DryRun{}
+  client := clearbit.NewClient(clearbit.WithDryRun(dryRun))
+  ...
+  fmt.Println(dryRun.Summary())
+
+Changes of the API payloads can be detected with WithStrictDecoding, which
+reports every key of a response missing from the Go types, or in tests with
+DetectSchemaDrift run on saved sample payloads.
+
+Person, Company, Risk, Reveal and NameToDomain results keep the payload they
+were decoded from in their Raw field and any key without a Go field in Extras,
+so the full payloads can be stored. The nullable package decodes these
+payloads into Person and Company types telling apart missing attributes from
+zero values.
+
+Discovery queries can be built and checked with the discovery package before
+being sent, e.g. discovery.Tech("stripe").And(discovery.EmployeesRange(50, 200)).
+
+FindContacts chains the NameToDomain, Company and Prospector APIs to go from
+a company name to the emails of its decision-makers.
+
+A Resolver finds the most likely domain of a company typed by a user, e.g.
+"Acme Corp (US)", from the Autocomplete and NameToDomain APIs.
+
+The autocomplete package serves Autocomplete suggestions to front-ends over
+HTTP, with caching, rate limits and CORS.
+
+Every API field of the Client is an interface (PersonFinder, CompanyFinder,
+...) so it can be swapped for one of the map backed fakes, such as
+FakePersonFinder, or wrapped in your own decorator.
+
 See the examples for more details and how to use each API.
 
 */
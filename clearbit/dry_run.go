@@ -0,0 +1,115 @@
+package clearbit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ErrDryRun is returned by every call made by a client in dry run mode,
+// unless the DryRun returns synthetic results.
+type ErrDryRun struct {
+	Request DryRunRequest
+}
+
+func (e *ErrDryRun) Error() string {
+	return fmt.Sprintf("clearbit: dry run, %s %s not sent", e.Request.Method, e.Request.URL)
+}
+
+// DryRunRequest is a request recorded instead of being sent
+type DryRunRequest struct {
+	Service   string
+	Operation string
+	Method    string
+	URL       string
+	Query     url.Values
+	// Header holds the request headers, with the Authorization header
+	// scrubbed
+	Header http.Header
+}
+
+// DryRunSummary counts the calls recorded by a DryRun
+type DryRunSummary struct {
+	// Calls is the number of calls per service
+	Calls map[string]int
+	// Billable is the number of calls that would have consumed credits if
+	// they had found a record
+	Billable int
+}
+
+// DryRun records the requests a client would make without sending them.
+type DryRun struct {
+	// Synthetic makes the calls succeed with an empty result and a 200 OK
+	// response instead of returning an ErrDryRun.
+	Synthetic bool
+
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+// WithDryRun makes the client record its calls into d instead of sending
+// them. The params are still validated and the calls still go through the
+// middleware chain.
+func WithDryRun(d *DryRun) func(*config) {
+	return func(c *config) {
+		c.dryRun = d
+	}
+}
+
+// send replaces the network as the last Handler of the chain
+func (d *DryRun) send(call *Call) (*http.Response, error) {
+	req, err := request(call)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := DryRunRequest{
+		Service:   call.Service,
+		Operation: call.Operation,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Query:     req.URL.Query(),
+		Header:    scrubHeader(req.Header),
+	}
+
+	d.mu.Lock()
+	d.requests = append(d.requests, recorded)
+	d.mu.Unlock()
+
+	if d.Synthetic {
+		resp := fakeResponse(http.StatusOK)
+		resp.Request = req
+		return resp, nil
+	}
+	return nil, &ErrDryRun{Request: recorded}
+}
+
+// Requests returns the requests recorded so far
+func (d *DryRun) Requests() []DryRunRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DryRunRequest(nil), d.requests...)
+}
+
+// Summary counts the requests recorded so far
+func (d *DryRun) Summary() DryRunSummary {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	summary := DryRunSummary{Calls: map[string]int{}}
+	for _, r := range d.requests {
+		summary.Calls[r.Service]++
+		if billableServices[r.Service] {
+			summary.Billable++
+		}
+	}
+	return summary
+}
+
+// Reset forgets the requests recorded so far
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests = nil
+}
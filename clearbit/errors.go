@@ -69,3 +69,34 @@ func relevantError(httpError error, ae apiError) error {
 	}
 	return ae
 }
+
+// ValidationError is returned, without sending the request, when the params
+// of a call are invalid. Params are only validated by the clients created
+// with WithValidation or WithDryRun.
+type ValidationError struct {
+	// Field is the name of the query parameter, e.g. "email"
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("clearbit: invalid %s: %s", e.Field, e.Message)
+}
+
+// WithValidation checks the params of every call before sending it, e.g.
+// that an email is given to Person.Find, so invalid calls fail with a
+// ValidationError instead of being sent. Clients in dry run mode always
+// validate the params.
+func WithValidation() func(*config) {
+	return func(c *config) {
+		c.validate = true
+	}
+}
+
+// required returns a ValidationError if value is empty
+func required(field, value string) error {
+	if value == "" {
+		return &ValidationError{Field: field, Message: "is required"}
+	}
+	return nil
+}
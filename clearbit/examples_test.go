@@ -1,10 +1,18 @@
 package clearbit_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/clearbit/clearbit-go/clearbit"
@@ -215,6 +223,50 @@ func ExampleNameToDomainService_Find_output() {
 	// Output: uber.com 200 OK
 }
 
+func ExampleNameToDomain_UnmarshalJSON_output() {
+	result := clearbit.NameToDomain{}
+	_ = json.Unmarshal([]byte(`{
+		"domain": "uber.com",
+		"logo": "https://logo.clearbit.com/uber.com",
+		"name": "Uber",
+		"score": 0.92
+	}`), &result)
+
+	fmt.Println(result.Name, result.Domain, result.Logo)
+	fmt.Println(string(result.Extras["score"]))
+
+	// Output:
+	// Uber uber.com https://logo.clearbit.com/uber.com
+	// 0.92
+}
+
+func ExampleFindDomains_output() {
+	finder := &clearbit.FakeNameToDomainFinder{
+		Domains: map[string]*clearbit.NameToDomain{
+			"Uber":     {Name: "Uber", Domain: "uber.com"},
+			"Clearbit": {Name: "Clearbit", Domain: "clearbit.com"},
+		},
+	}
+
+	names := []string{"Uber", "Initech", "Clearbit"}
+	for _, r := range clearbit.FindDomains(context.Background(), finder, names, 2) {
+		var notFound *clearbit.ErrNameNotFound
+		switch {
+		case errors.As(r.Err, &notFound):
+			fmt.Println(r.Name, "not found")
+		case r.Err != nil:
+			fmt.Println(r.Name, r.Err)
+		default:
+			fmt.Println(r.Name, r.Result.Domain)
+		}
+	}
+
+	// Output:
+	// Uber uber.com
+	// Initech not found
+	// Clearbit clearbit.com
+}
+
 func ExampleProspectorService_Search_output() {
 	client := clearbit.NewClient(clearbit.WithBaseURLs(map[string]string{"prospector": clearbitServer.URL}))
 	results, resp, err := client.Prospector.Search(clearbit.ProspectorSearchParams{
@@ -234,7 +286,7 @@ func ExampleProspectorService_Search_withRoles_Output() {
 	client := clearbit.NewClient(clearbit.WithBaseURLs(map[string]string{"prospector": clearbitServer.URL}))
 	results, resp, err := client.Prospector.Search(clearbit.ProspectorSearchParams{
 		Domain: "clearbit.com",
-		Roles:  []string{"sales", "engineering"},
+		Roles:  []clearbit.Role{clearbit.RoleSales, clearbit.RoleEngineering},
 	})
 
 	if err == nil {
@@ -246,6 +298,26 @@ func ExampleProspectorService_Search_withRoles_Output() {
 	// Output: 5 200 OK
 }
 
+func ExampleProspectorSearchParams_Validate_output() {
+	params := clearbit.ProspectorSearchParams{
+		Domain:      "clearbit.com",
+		Roles:       []clearbit.Role{clearbit.RoleSales, clearbit.RoleEngineering},
+		Seniorities: []clearbit.Seniority{clearbit.SeniorityExecutive},
+	}
+	fmt.Println(params.Validate())
+
+	params.Role = clearbit.RoleFinance
+	fmt.Println(params.Validate())
+
+	params.Role, params.Seniorities = "", []clearbit.Seniority{"senior"}
+	fmt.Println(params.Validate())
+
+	// Output:
+	// <nil>
+	// clearbit: invalid role: cannot be used with roles
+	// clearbit: invalid seniority: unknown seniority "senior"
+}
+
 func ExampleCompanyService_Find_output() {
 	client := clearbit.NewClient(clearbit.WithBaseURLs(map[string]string{"company": clearbitServer.URL}))
 	results, resp, err := client.Company.Find(clearbit.CompanyFindParams{
@@ -305,3 +377,623 @@ func ExampleDiscoveryService_Search_output() {
 
 	// Output: clearbit.com 200 OK
 }
+
+func ExampleDiscoveryService_Search_sorted_output() {
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"discovery": clearbitServer.URL}),
+		clearbit.WithValidation(),
+	)
+	_, resp, err := client.Discovery.Search(clearbit.DiscoverySearchParams{
+		Query:    "tech:stripe",
+		Sort:     clearbit.DiscoverySortEmployeesDesc,
+		PageSize: 50,
+	})
+	if err == nil {
+		fmt.Println(resp.Request.URL.RawQuery)
+	}
+
+	_, _, err = client.Discovery.Search(clearbit.DiscoverySearchParams{
+		Query:    "tech:stripe",
+		PageSize: 500,
+	})
+	fmt.Println(err)
+
+	_, _, err = client.Discovery.Search(clearbit.DiscoverySearchParams{
+		Query: "tech:stripe",
+		Sort:  "employees",
+	})
+	fmt.Println(err)
+
+	// Output:
+	// page_size=50&query=tech%3Astripe&sort=employees_desc
+	// clearbit: invalid page_size: must be between 1 and 100
+	// clearbit: invalid sort: unknown sort order "employees"
+}
+
+func ExampleFakeCompanyFinder_output() {
+	client := &clearbit.Client{
+		Company: &clearbit.FakeCompanyFinder{
+			Companies: map[string]*clearbit.Company{
+				"clearbit.com": {Name: "Clearbit"},
+			},
+		},
+	}
+
+	results, resp, err := client.Company.Find(clearbit.CompanyFindParams{
+		Domain: "clearbit.com",
+	})
+	fmt.Println(results.Name, resp.Status, err)
+
+	_, resp, err = client.Company.Find(clearbit.CompanyFindParams{
+		Domain: "example.com",
+	})
+	fmt.Println(resp.Status, err)
+
+	// Output:
+	// Clearbit 200 OK <nil>
+	// 404 Not Found clearbit: unknown_record Unknown company for "example.com"
+}
+
+func ExampleClient_FindContacts_output() {
+	client := &clearbit.Client{
+		NameToDomain: &clearbit.FakeNameToDomainFinder{
+			Domains: map[string]*clearbit.NameToDomain{
+				"Clearbit": {Domain: "clearbit.com"},
+				"J.Crew":   {Domain: "jcrew.com"},
+			},
+		},
+		Company: &clearbit.FakeCompanyFinder{},
+		Prospector: &clearbit.FakeProspectorSearcher{
+			Results: map[string]clearbit.ProspectorResponse{"clearbit.com": {
+				Total: 1,
+				Results: []clearbit.Prospect{{
+					Name:  clearbit.PersonName{FullName: "Alex MacCaw"},
+					Email: "alex@clearbit.com",
+					Role:  clearbit.RoleCEO,
+				}},
+			}},
+		},
+	}
+
+	account, err := client.FindContacts(context.Background(), "Clearbit", clearbit.ContactQuery{
+		Roles:       []clearbit.Role{clearbit.RoleCEO, clearbit.RoleSales},
+		Seniorities: []clearbit.Seniority{clearbit.SeniorityExecutive},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(account.Domain)
+	for _, contact := range account.Contacts {
+		fmt.Println(contact.Name.FullName, contact.Email, contact.Role)
+	}
+	for _, serr := range account.Errors {
+		fmt.Println(serr)
+	}
+
+	_, err = client.FindContacts(context.Background(), "Initech", clearbit.ContactQuery{})
+	fmt.Println(err)
+
+	// names with a dot are looked up unless they end with a known TLD
+	for _, query := range []string{"J.Crew", "https://www.clearbit.com/about"} {
+		account, _ = client.FindContacts(context.Background(), query, clearbit.ContactQuery{SkipCompany: true})
+		fmt.Println(account.Domain)
+	}
+
+	// Output:
+	// clearbit.com
+	// Alex MacCaw alex@clearbit.com ceo
+	// clearbit: company stage: unknown_record Unknown company for "clearbit.com"
+	// clearbit: domain stage: no domain found for "Initech"
+	// jcrew.com
+	// clearbit.com
+}
+
+func ExampleResolver_output() {
+	fmt.Println(clearbit.NormalizeCompanyName("Acme Corp. (US)"))
+
+	resolver := clearbit.NewResolver(&clearbit.Client{
+		Autocomplete: &clearbit.FakeAutocompleter{
+			Suggestions: map[string][]clearbit.AutocompleteItem{"acme": {
+				{Name: "Acme", Domain: "acme.com", Logo: "https://logo.clearbit.com/acme.com"},
+				{Name: "Acme Brick", Domain: "acmebrick.com"},
+			}},
+		},
+		NameToDomain: &clearbit.FakeNameToDomainFinder{
+			Domains: map[string]*clearbit.NameToDomain{"acme": {Domain: "acme.com"}},
+		},
+	})
+
+	res, err := resolver.Resolve(context.Background(), "Acme Corp. (US)")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range res.Candidates {
+		fmt.Printf("%s %.2f %v\n", c.Domain, c.Score, c.Sources)
+	}
+	fmt.Printf("confidence %.2f ambiguous %v\n", res.Confidence, res.Ambiguous)
+
+	// Output:
+	// acme
+	// acme.com 1.00 [autocomplete nameToDomain]
+	// acmebrick.com 0.24 [autocomplete]
+	// confidence 1.00 ambiguous false
+}
+
+func ExampleWithMiddleware_output() {
+	logCalls := func(next clearbit.Handler) clearbit.Handler {
+		return func(call *clearbit.Call) (*http.Response, error) {
+			resp, err := next(call)
+			company := call.Result.(*clearbit.Company)
+			fmt.Println(call.Service, call.Operation, call.Params, company.Name, resp.Status)
+			return resp, err
+		}
+	}
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"company": clearbitServer.URL}),
+		clearbit.WithMiddleware(logCalls),
+	)
+	_, _, _ = client.Company.Find(clearbit.CompanyFindParams{
+		Domain: "clearbit.com",
+	})
+
+	// Output: company find {clearbit.com} Clearbit 200 OK
+}
+
+func ExampleWithLogger_output() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// drop the attributes changing at every run
+			if a.Key == slog.TimeKey || a.Key == "latency" {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"person": clearbitServer.URL}),
+		clearbit.WithLogger(logger),
+	)
+	_, _, _ = client.Person.Find(clearbit.PersonFindParams{
+		Email: "alex@clearbit.com",
+	})
+
+	// Output: level=INFO msg="clearbit call" service=person operation=find params.email=***@clearbit.com attempts=1 status=200
+}
+
+func ExampleWithLogger_errors_output() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error": {
+			"type": "invalid_email",
+			"message": "Invalid email alex@clearbit.com from 10.1.2.3, see bob@example.com"
+		}}`))
+	}))
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == "error" || len(groups) > 0 {
+				return a
+			}
+			return slog.Attr{}
+		},
+	}))
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"risk": server.URL}),
+		clearbit.WithLogger(logger),
+	)
+	_, _, _ = client.Risk.Calculate(clearbit.RiskCalculateParams{
+		Email: "alex@clearbit.com",
+		IP:    "10.1.2.3",
+	})
+
+	// Output: params.email=***@clearbit.com params.ip=10.1.2.0/24 params.country_code="" params.zip_code="" params.given_name="" params.family_name="" params.name="" error="clearbit: invalid_email Invalid email ***@clearbit.com from 10.1.2.0/24, see ***@example.com"
+}
+
+func ExampleMetrics_output() {
+	metrics := clearbit.NewMetrics(1, 10)
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"reveal": clearbitServer.URL}),
+		clearbit.WithMetrics(metrics),
+	)
+	_, _, _ = client.Reveal.Find(clearbit.RevealFindParams{
+		IP: "104.193.168.24",
+	})
+
+	// metrics also implements http.Handler to be scraped by Prometheus
+	b := &strings.Builder{}
+	_ = metrics.WritePrometheus(b)
+
+	for _, line := range strings.Split(b.String(), "\n") {
+		// skip the comments and the latency sum changing at every run
+		if strings.HasPrefix(line, "clearbit_") && !strings.Contains(line, "_sum") {
+			fmt.Println(line)
+		}
+	}
+
+	// Output:
+	// clearbit_requests_total{service="reveal",operation="find",outcome="ok"} 1
+	// clearbit_request_duration_seconds_bucket{service="reveal",outcome="ok",le="1"} 0
+	// clearbit_request_duration_seconds_bucket{service="reveal",outcome="ok",le="10"} 1
+	// clearbit_request_duration_seconds_bucket{service="reveal",outcome="ok",le="+Inf"} 1
+	// clearbit_request_duration_seconds_count{service="reveal",outcome="ok"} 1
+	// clearbit_cache_requests_total{service="reveal",result="hit"} 0
+	// clearbit_cache_requests_total{service="reveal",result="miss"} 1
+}
+
+type exampleTracer struct{}
+
+func (exampleTracer) Start(ctx context.Context, name string) (context.Context, clearbit.Span) {
+	fmt.Println("start", name)
+	return ctx, &exampleSpan{}
+}
+
+type exampleSpan struct{}
+
+func (s *exampleSpan) SetAttribute(key string, value interface{}) {
+	fmt.Println("attribute", key, value)
+}
+
+func (s *exampleSpan) AddEvent(name string)  { fmt.Println("event", name) }
+func (s *exampleSpan) RecordError(err error) { fmt.Println("error", err) }
+func (s *exampleSpan) End()                  { fmt.Println("end") }
+
+func (s *exampleSpan) SpanContext() clearbit.SpanContext {
+	return clearbit.SpanContext{
+		TraceID: [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  [8]byte{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+}
+
+func ExampleWithTracer_output() {
+	printTraceparent := func(next clearbit.Handler) clearbit.Handler {
+		return func(call *clearbit.Call) (*http.Response, error) {
+			fmt.Println("traceparent", call.Header.Get("traceparent"))
+			return next(call)
+		}
+	}
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"prospector": clearbitServer.URL}),
+		clearbit.WithTracer(exampleTracer{}),
+		clearbit.WithMiddleware(printTraceparent),
+	)
+	_, _, _ = client.Prospector.Search(clearbit.ProspectorSearchParams{
+		Domain: "clearbit.com",
+	})
+
+	// Output:
+	// start clearbit.prospector.search
+	// attribute clearbit.service prospector
+	// attribute clearbit.operation search
+	// traceparent 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	// attribute clearbit.outcome ok
+	// attribute clearbit.attempts 1
+	// attribute http.status_code 200
+	// end
+}
+
+func ExampleBudget_output() {
+	budget, _ := clearbit.NewBudget(
+		clearbit.WithServiceLimits("company", clearbit.BudgetLimits{Daily: 1}),
+	)
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"company": clearbitServer.URL}),
+		clearbit.WithBudget(budget),
+	)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.Company.Find(clearbit.CompanyFindParams{
+			Domain: "clearbit.com",
+		})
+		fmt.Println(err)
+	}
+
+	usage := budget.Usage().Services["company"]
+	fmt.Println(usage.Daily, usage.Monthly)
+
+	// Output:
+	// <nil>
+	// clearbit: daily budget exceeded for company (1/1 calls)
+	// 1 1
+}
+
+func ExampleBudget_file_output() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "budget")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "budget.json")
+
+	budget, _ := clearbit.NewBudget(
+		clearbit.WithBudgetFile(path),
+		clearbit.WithServiceLimits(clearbit.BudgetCombined, clearbit.BudgetLimits{Daily: 1}),
+	)
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"company": server.URL, "person": server.URL}),
+		clearbit.WithBudget(budget),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = client.Company.Find(clearbit.CompanyFindParams{Domain: "clearbit.com"})
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.Person.FindCombined(clearbit.PersonFindParams{Email: "alex@clearbit.com"})
+		fmt.Println(err)
+	}
+
+	// the counters survive a restart
+	restarted, _ := clearbit.NewBudget(clearbit.WithBudgetFile(path))
+	usage := restarted.Usage()
+	fmt.Println(usage.Services["company"].Daily, usage.Services["combined"].Daily, usage.Services["person"].Daily)
+
+	// Output:
+	// <nil>
+	// clearbit: daily budget exceeded for combined (1/1 calls)
+	// 20 1 0
+}
+
+func ExampleWithDryRun_output() {
+	dryRun := &clearbit.DryRun{}
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"person": "https://person.example.com"}),
+		clearbit.WithDryRun(dryRun),
+	)
+
+	_, _, err := client.Person.Find(clearbit.PersonFindParams{
+		Email: "alex@clearbit.com",
+	})
+	fmt.Println(err)
+
+	_, _, err = client.Person.Find(clearbit.PersonFindParams{})
+	fmt.Println(err)
+
+	_, _, err = client.Autocomplete.Suggest(clearbit.AutocompleteSuggestParams{
+		Query: "clearbit",
+	})
+	fmt.Println(err)
+
+	for _, r := range dryRun.Requests() {
+		fmt.Println(r.Service, r.Operation, r.Method, r.Query.Encode())
+	}
+	summary := dryRun.Summary()
+	fmt.Println(summary.Calls, summary.Billable)
+
+	// Output:
+	// clearbit: dry run, GET https://person.example.com/v2/people/find?email=alex%40clearbit.com not sent
+	// clearbit: invalid email: is required
+	// clearbit: dry run, GET https://autocomplete.clearbit.com/v1/companies/suggest?query=clearbit not sent
+	// person find GET email=alex%40clearbit.com
+	// autocomplete suggest GET query=clearbit
+	// map[autocomplete:1 person:1] 1
+}
+
+func ExampleKeyPool_output() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, _, _ := r.BasicAuth(); key != "sk_sales" {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"error": {"type": "payment_required", "message": "Out of credits"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "Clearbit"}`))
+	}))
+	defer server.Close()
+
+	pool := clearbit.NewKeyPool(clearbit.Priority)
+	pool.Add("marketing", "sk_marketing")
+	pool.Add("sales", "sk_sales")
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"company": server.URL}),
+		clearbit.WithKeyPool(pool),
+	)
+	results, resp, err := client.Company.Find(clearbit.CompanyFindParams{
+		Domain: "clearbit.com",
+	})
+	fmt.Println(results.Name, resp.Status, err)
+
+	for _, usage := range pool.Usage() {
+		fmt.Println(usage.Name, usage.Requests, usage.Failures, usage.LastStatus)
+	}
+
+	// Output:
+	// Clearbit 200 OK <nil>
+	// marketing 1 1 402
+	// sales 1 0 200
+}
+
+func ExampleKeyPool_revoked_output() {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _, _ := r.BasicAuth()
+		keys = append(keys, key)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"type": "unauthorized", "message": "Invalid API key"}}`))
+	}))
+	defer server.Close()
+
+	pool := clearbit.NewKeyPool(clearbit.Priority)
+	pool.Add("marketing", "sk_marketing")
+	pool.Add("sales", "sk_sales")
+
+	client := clearbit.NewClient(
+		clearbit.WithAPIKey("sk_default"),
+		clearbit.WithBaseURLs(map[string]string{"company": server.URL}),
+		clearbit.WithKeyPool(pool),
+	)
+	for i := 0; i < 2; i++ {
+		_, _, err := client.Company.Find(clearbit.CompanyFindParams{
+			Domain: "clearbit.com",
+		})
+		fmt.Println(err)
+	}
+
+	var noKey *clearbit.ErrNoUsableKey
+	_, _, err := client.Company.Find(clearbit.CompanyFindParams{Domain: "clearbit.com"})
+	fmt.Println(errors.As(err, &noKey), keys)
+
+	// Output:
+	// clearbit: unauthorized Invalid API key
+	// clearbit: no usable key in the key pool (2 disabled)
+	// true [sk_marketing sk_sales]
+}
+
+func ExampleCallOption_output() {
+	dryRun := &clearbit.DryRun{}
+	client := clearbit.NewClient(clearbit.WithDryRun(dryRun))
+
+	_, _, _ = client.Person.Find(
+		clearbit.PersonFindParams{Email: "alex@clearbit.com"},
+		clearbit.WithCallTimeout(2*time.Second),
+		clearbit.WithCallAPIKey("sk_1234567890123123"),
+		clearbit.WithIdempotencyKey("lead-42"),
+		clearbit.WithWebhookID("lead-42"),
+	)
+
+	r := dryRun.Requests()[0]
+	fmt.Println(r.Query.Encode())
+	fmt.Println(r.Header.Get("Idempotency-Key"), r.Header.Get("Authorization"))
+
+	// Output:
+	// email=alex%40clearbit.com&webhook_id=lead-42
+	// lead-42 [REDACTED]
+}
+
+func ExampleNewClientFromConfig_output() {
+	dir, _ := ioutil.TempDir("", "config")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "clearbit.toml")
+	_ = ioutil.WriteFile(path, []byte(`
+default_profile = "prod"
+
+[prod]
+api_key = "sk_1234567890123123" # the "live" key
+timeout = "10s"
+
+[staging]
+api_key = "sk_3213210987654321"
+timeout = "ten \"seconds\""
+base_urls.person = "localhost:8080"
+base_urls.people = "http://localhost:8080"
+`), 0644)
+
+	// point the prod profile to the mock server
+	os.Setenv("CLEARBIT_BASE_URL_PERSON", clearbitServer.URL)
+	defer os.Unsetenv("CLEARBIT_BASE_URL_PERSON")
+
+	client, err := clearbit.NewClientFromConfig(path, "")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	results, resp, err := client.Person.Find(clearbit.PersonFindParams{
+		Email: "alex@clearbit.com",
+	})
+	if err == nil {
+		fmt.Println(results.Name.FullName, resp.Status)
+	}
+
+	os.Unsetenv("CLEARBIT_BASE_URL_PERSON")
+	_, err = clearbit.NewClientFromConfig(path, "staging")
+	var cerr *clearbit.ConfigError
+	if errors.As(err, &cerr) {
+		for _, problem := range cerr.Problems {
+			fmt.Println(problem)
+		}
+	}
+
+	// Output:
+	// Alex MacCaw 200 OK
+	// timeout "ten \"seconds\"" is not a duration such as "10s"
+	// base_urls: unknown service "people", valid services are autocomplete, person, company, discovery, prospector, reveal, risk, nameToDomain
+	// base_urls.person: "localhost:8080" is not an http(s) URL
+}
+
+func ExampleWithStrictDecoding_output() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"name": "Clearbit",
+			"metrics": {"employees": 150, "headcountGrowth": 0.2},
+			"unicorn": false
+		}`))
+	}))
+	defer server.Close()
+
+	client := clearbit.NewClient(
+		clearbit.WithBaseURLs(map[string]string{"company": server.URL}),
+		clearbit.WithStrictDecoding(func(f clearbit.UnknownField) {
+			fmt.Println(f.Service, f.Operation, f.Path)
+		}),
+	)
+	_, _, _ = client.Company.Find(clearbit.CompanyFindParams{
+		Domain: "clearbit.com",
+	})
+
+	// Output:
+	// company find metrics.headcountGrowth
+	// company find unicorn
+}
+
+func ExampleDetectSchemaDrift_output() {
+	dir, _ := ioutil.TempDir("", "samples")
+	defer os.RemoveAll(dir)
+
+	_ = ioutil.WriteFile(filepath.Join(dir, "alex.json"), []byte(`{
+		"name": {"fullName": "Alex MacCaw", "nickname": "maccman"}
+	}`), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "harlow.json"), []byte(`{
+		"name": {"fullName": "Harlow Ward"}
+	}`), 0644)
+
+	drift, _ := clearbit.DetectSchemaDrift(dir, clearbit.Person{})
+	for _, d := range drift {
+		fmt.Println(filepath.Base(d.File), d.Fields)
+	}
+
+	// Output: alex.json [name.nickname]
+}
+
+func ExampleCompany_UnmarshalJSON_output() {
+	company := clearbit.Company{}
+	_ = json.Unmarshal([]byte(`{"name": "Clearbit", "unicorn": false}`), &company)
+
+	fmt.Println(company.Name, string(company.Extras["unicorn"]))
+	fmt.Println(string(company.Raw))
+
+	// Output:
+	// Clearbit false
+	// {"name": "Clearbit", "unicorn": false}
+}
+
+func ExampleCompanyIdentifiers_UnmarshalJSON_output() {
+	company := clearbit.Company{}
+	err := json.Unmarshal([]byte(`{
+		"name": "Clearbit",
+		"identifiers": {"usEIN": "46-1234567", "usCIK": 1234567, "duns": "123456789"}
+	}`), &company)
+
+	ids := company.Identifiers
+	fmt.Println(err, company.Name, ids.UsEIN)
+	fmt.Println(string(ids.Others["usCIK"]), string(ids.Others["duns"]))
+
+	// Output:
+	// <nil> Clearbit 46-1234567
+	// 1234567 "123456789"
+}
@@ -0,0 +1,170 @@
+package clearbit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// The fakes below implement the service interfaces from in-memory maps so
+// code using a Client can be tested without a network stub:
+//
+//   client := &clearbit.Client{
+//       Person: &clearbit.FakePersonFinder{
+//           People: map[string]*clearbit.Person{"alex@clearbit.com": {ID: "1"}},
+//       },
+//   }
+//
+// Lookups missing from the maps behave like the API does for unknown records:
+// a 404 response and an "unknown_record" error. Call options are ignored.
+
+// FakePersonFinder is a PersonFinder backed by maps keyed by email.
+type FakePersonFinder struct {
+	People   map[string]*Person
+	Combined map[string]*PersonCompany
+}
+
+// Find returns the person stored for params.Email
+func (f *FakePersonFinder) Find(params PersonFindParams, opts ...CallOption) (*Person, *http.Response, error) {
+	if p, ok := f.People[params.Email]; ok {
+		return p, fakeResponse(http.StatusOK), nil
+	}
+	return new(Person), fakeResponse(http.StatusNotFound), fakeNotFound("person", params.Email)
+}
+
+// FindCombined returns the person and company stored for params.Email
+func (f *FakePersonFinder) FindCombined(params PersonFindParams, opts ...CallOption) (*PersonCompany, *http.Response, error) {
+	if pc, ok := f.Combined[params.Email]; ok {
+		return pc, fakeResponse(http.StatusOK), nil
+	}
+	return new(PersonCompany), fakeResponse(http.StatusNotFound), fakeNotFound("person", params.Email)
+}
+
+// FakeCompanyFinder is a CompanyFinder backed by a map keyed by domain.
+type FakeCompanyFinder struct {
+	Companies map[string]*Company
+}
+
+// Find returns the company stored for params.Domain
+func (f *FakeCompanyFinder) Find(params CompanyFindParams, opts ...CallOption) (*Company, *http.Response, error) {
+	if c, ok := f.Companies[params.Domain]; ok {
+		return c, fakeResponse(http.StatusOK), nil
+	}
+	return new(Company), fakeResponse(http.StatusNotFound), fakeNotFound("company", params.Domain)
+}
+
+// FakeDiscoverySearcher is a DiscoverySearcher backed by a map keyed by
+// query. Unknown queries return an empty page.
+type FakeDiscoverySearcher struct {
+	Results map[string]*DiscoveryResults
+}
+
+// Search returns the results stored for params.Query
+func (f *FakeDiscoverySearcher) Search(params DiscoverySearchParams, opts ...CallOption) (*DiscoveryResults, *http.Response, error) {
+	if r, ok := f.Results[params.Query]; ok {
+		return r, fakeResponse(http.StatusOK), nil
+	}
+	return new(DiscoveryResults), fakeResponse(http.StatusOK), nil
+}
+
+// FakeProspectorSearcher is a ProspectorSearcher backed by a map keyed by
+// domain. Unknown domains return an empty response.
+type FakeProspectorSearcher struct {
+	Results map[string]ProspectorResponse
+}
+
+// Search returns the response stored for params.Domain
+func (f *FakeProspectorSearcher) Search(params ProspectorSearchParams, opts ...CallOption) (ProspectorResponse, *http.Response, error) {
+	return f.Results[params.Domain], fakeResponse(http.StatusOK), nil
+}
+
+// FakeRiskCalculator is a RiskCalculator backed by a map keyed by email.
+type FakeRiskCalculator struct {
+	Risks map[string]*Risk
+}
+
+// Calculate returns the risk stored for params.Email
+func (f *FakeRiskCalculator) Calculate(params RiskCalculateParams, opts ...CallOption) (*Risk, *http.Response, error) {
+	if r, ok := f.Risks[params.Email]; ok {
+		return r, fakeResponse(http.StatusOK), nil
+	}
+	return new(Risk), fakeResponse(http.StatusNotFound), fakeNotFound("risk", params.Email)
+}
+
+// FakeRevealFinder is a RevealFinder backed by a map keyed by IP.
+type FakeRevealFinder struct {
+	Reveals map[string]*Reveal
+}
+
+// Find returns the reveal stored for params.IP
+func (f *FakeRevealFinder) Find(params RevealFindParams, opts ...CallOption) (*Reveal, *http.Response, error) {
+	if r, ok := f.Reveals[params.IP]; ok {
+		return r, fakeResponse(http.StatusOK), nil
+	}
+	return new(Reveal), fakeResponse(http.StatusNotFound), fakeNotFound("reveal", params.IP)
+}
+
+// FakeAutocompleter is an Autocompleter backed by a map keyed by query.
+// Unknown queries return no suggestions.
+type FakeAutocompleter struct {
+	Suggestions map[string][]AutocompleteItem
+}
+
+// Suggest returns the suggestions stored for params.Query
+func (f *FakeAutocompleter) Suggest(params AutocompleteSuggestParams, opts ...CallOption) ([]AutocompleteItem, *http.Response, error) {
+	items := f.Suggestions[params.Query]
+	if items == nil {
+		items = []AutocompleteItem{}
+	}
+	return items, fakeResponse(http.StatusOK), nil
+}
+
+// FakeNameToDomainFinder is a NameToDomainFinder backed by a map keyed by
+// company name.
+type FakeNameToDomainFinder struct {
+	Domains map[string]*NameToDomain
+}
+
+// Find returns the domain stored for params.Name
+func (f *FakeNameToDomainFinder) Find(params NameToDomainFindParams, opts ...CallOption) (*NameToDomain, *http.Response, error) {
+	if d, ok := f.Domains[params.Name]; ok {
+		return d, fakeResponse(http.StatusOK), nil
+	}
+	return nil, fakeResponse(http.StatusNotFound), &ErrNameNotFound{
+		Name: params.Name,
+		Err:  fakeNotFound("name to domain", params.Name),
+	}
+}
+
+var (
+	_ PersonFinder       = (*FakePersonFinder)(nil)
+	_ CompanyFinder      = (*FakeCompanyFinder)(nil)
+	_ DiscoverySearcher  = (*FakeDiscoverySearcher)(nil)
+	_ ProspectorSearcher = (*FakeProspectorSearcher)(nil)
+	_ RiskCalculator     = (*FakeRiskCalculator)(nil)
+	_ RevealFinder       = (*FakeRevealFinder)(nil)
+	_ Autocompleter      = (*FakeAutocompleter)(nil)
+	_ NameToDomainFinder = (*FakeNameToDomainFinder)(nil)
+)
+
+// fakeResponse returns a bodiless http.Response with the given status
+func fakeResponse(status int) *http.Response {
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+}
+
+// fakeNotFound returns the error the API gives for unknown records
+func fakeNotFound(resource, key string) error {
+	return apiError{Errors: []ErrorDetail{{
+		Type:    "unknown_record",
+		Message: fmt.Sprintf("Unknown %s for %q", resource, key),
+	}}}
+}
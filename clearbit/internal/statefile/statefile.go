@@ -0,0 +1,50 @@
+/*
+Package statefile writes the state files of the clearbit packages, such as
+the budget counters and the saved Discovery searches.
+*/
+package statefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// File is a state file rewritten as a whole on every save
+type File struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// Save writes the snapshot returned by the snapshot function to the file.
+//
+// Saves are serialized and the snapshot is taken once the previous save is
+// done, so the file always ends up holding the latest state even when saves
+// run concurrently. The data goes to a temporary file renamed over the file,
+// so a crash never leaves a truncated file behind.
+func (f *File) Save(snapshot func() ([]byte, error)) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := snapshot()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,268 @@
+package clearbit
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// KeySelection is the strategy used by a KeyPool to pick the key of a call
+type KeySelection int
+
+const (
+	// RoundRobin spreads the calls evenly over the keys
+	RoundRobin KeySelection = iota
+	// Priority always uses the first available key in the order they were
+	// added, the next ones being only used for failover.
+	Priority
+)
+
+// DefaultKeyCooldown is how long a key answered with 402 Payment Required or
+// 429 Too Many Requests is skipped, when the response has no Retry-After.
+const DefaultKeyCooldown = time.Minute
+
+// KeyUsage reports the calls made with one key of a KeyPool
+type KeyUsage struct {
+	Name     string
+	Requests int
+	// Failures counts the requests answered with 401, 402 or 429 that
+	// triggered a failover to another key.
+	Failures     int
+	LastStatus   int
+	BenchedUntil time.Time
+	// Disabled is true once the key was answered with 401 Unauthorized. It
+	// is not used again until replaced with Rotate or Add.
+	Disabled bool
+}
+
+// ErrNoUsableKey is returned, without sending the request, by the calls made
+// while the KeyPool is empty or all its keys are disabled.
+type ErrNoUsableKey struct {
+	// Disabled is the number of keys of the pool disabled after a 401
+	Disabled int
+}
+
+func (e *ErrNoUsableKey) Error() string {
+	return fmt.Sprintf("clearbit: no usable key in the key pool (%d disabled)", e.Disabled)
+}
+
+type poolKey struct {
+	name  string
+	key   string
+	usage KeyUsage
+}
+
+// KeyPool holds several Clearbit API keys.
+//
+// A call answered with 401 Unauthorized, 402 Payment Required or 429 Too Many
+// Requests is retried with the next key of the pool. Keys answered with 402 or
+// 429 are skipped for a cooldown, while keys answered with 401, which are
+// revoked or invalid, are disabled until they are replaced with Rotate or Add.
+// Keys can be added, removed or rotated at any time without rebuilding the
+// Client.
+type KeyPool struct {
+	// Cooldown overrides DefaultKeyCooldown
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	selection KeySelection
+	keys      []*poolKey
+	next      int
+	now       func() time.Time
+}
+
+// NewKeyPool returns an empty KeyPool picking keys with the given strategy
+func NewKeyPool(selection KeySelection) *KeyPool {
+	return &KeyPool{
+		selection: selection,
+		now:       time.Now,
+	}
+}
+
+// WithKeyPool authenticates the calls with the keys of p instead of the key
+// given to WithAPIKey. The client key is never used as a fallback: while the
+// pool is empty or all its keys are disabled, calls fail with ErrNoUsableKey.
+func WithKeyPool(p *KeyPool) func(*config) {
+	return func(c *config) {
+		c.keyPool = p
+	}
+}
+
+// Add adds a key to the pool under the given name, e.g. the business unit it
+// belongs to. Adding a name already in the pool replaces its key, like
+// Rotate.
+func (p *KeyPool) Add(name, key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.name == name {
+			k.replace(key)
+			return
+		}
+	}
+	p.keys = append(p.keys, &poolKey{name: name, key: key, usage: KeyUsage{Name: name}})
+}
+
+// Rotate replaces the key stored under name, keeping its usage counters. It
+// returns false if there is no such key.
+func (p *KeyPool) Rotate(name, key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.name == name {
+			k.replace(key)
+			return true
+		}
+	}
+	return false
+}
+
+// replace sets a new key, making it usable again
+func (k *poolKey) replace(key string) {
+	k.key = key
+	k.usage.BenchedUntil = time.Time{}
+	k.usage.Disabled = false
+}
+
+// Remove removes the key stored under name
+func (p *KeyPool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, k := range p.keys {
+		if k.name == name {
+			p.keys = append(p.keys[:i], p.keys[i+1:]...)
+			return
+		}
+	}
+}
+
+// Usage returns the usage counters of each key, in the order they were added
+func (p *KeyPool) Usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]KeyUsage, 0, len(p.keys))
+	for _, k := range p.keys {
+		usage = append(usage, k.usage)
+	}
+	return usage
+}
+
+// candidates returns the keys to try for a call, in order, and the number of
+// disabled keys left out. Benched keys come last so they are only used when
+// every other key failed.
+func (p *KeyPool) candidates() ([]poolKey, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return nil, 0
+	}
+
+	start := 0
+	if p.selection == RoundRobin {
+		start = p.next % len(p.keys)
+		p.next++
+	}
+
+	now := p.now()
+	var available, benched []poolKey
+	disabled := 0
+	for i := range p.keys {
+		k := p.keys[(start+i)%len(p.keys)]
+		if k.usage.Disabled {
+			disabled++
+			continue
+		}
+		if now.Before(k.usage.BenchedUntil) {
+			benched = append(benched, *k)
+		} else {
+			available = append(available, *k)
+		}
+	}
+	return append(available, benched...), disabled
+}
+
+// record updates the usage counters of the key used for a request
+func (p *KeyPool) record(name string, resp *http.Response, failover bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, k := range p.keys {
+		if k.name != name {
+			continue
+		}
+		k.usage.Requests++
+		if resp != nil {
+			k.usage.LastStatus = resp.StatusCode
+		}
+		if !failover {
+			return
+		}
+		k.usage.Failures++
+		if resp.StatusCode == http.StatusUnauthorized {
+			k.usage.Disabled = true
+		} else {
+			k.usage.BenchedUntil = p.now().Add(p.cooldown(resp))
+		}
+		return
+	}
+}
+
+// cooldown returns how long a key answered with resp must be skipped
+func (p *KeyPool) cooldown(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if p.Cooldown > 0 {
+		return p.Cooldown
+	}
+	return DefaultKeyCooldown
+}
+
+// middleware returns the Middleware authenticating each call and failing
+// over to the next key when needed.
+func (p *KeyPool) middleware(next Handler) Handler {
+	return func(call *Call) (*http.Response, error) {
+		// the Autocomplete API is free and used without a key, and keys given
+		// with WithCallAPIKey take precedence over the pool
+		if call.Service == ServiceAutocomplete || call.apiKey != "" {
+			return next(call)
+		}
+
+		keys, disabled := p.candidates()
+		if len(keys) == 0 {
+			return nil, &ErrNoUsableKey{Disabled: disabled}
+		}
+
+		var resp *http.Response
+		var err error
+		for i, k := range keys {
+			call.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(k.key+":")))
+			resp, err = next(call)
+
+			failover := resp != nil && isKeyFailure(resp.StatusCode)
+			p.record(k.name, resp, failover)
+			if !failover || i == len(keys)-1 {
+				break
+			}
+		}
+		return resp, err
+	}
+}
+
+// isKeyFailure returns true for the statuses caused by the key rather than
+// the request
+func isKeyFailure(status int) bool {
+	switch status {
+	case http.StatusUnauthorized, http.StatusPaymentRequired, http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
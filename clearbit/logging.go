@@ -0,0 +1,282 @@
+package clearbit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Redaction selects how personal data is written to the logs
+type Redaction int
+
+const (
+	// RedactMask hides personal data, keeping only the domain of emails and
+	// the network of IPv4 addresses.
+	RedactMask Redaction = iota
+	// RedactHash replaces personal data by a short stable hash so lookups for
+	// the same value can be correlated.
+	RedactHash
+)
+
+// redacted replaces any value that cannot be partially masked
+const redacted = "[REDACTED]"
+
+// rateLimitRemainingHeader is the response header with the number of
+// requests left in the current rate limit window.
+const rateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+// loggerConfig represents all the parameters available to configure the
+// logging of a Clearbit client
+type loggerConfig struct {
+	logger       *slog.Logger
+	successLevel slog.Level
+	failureLevel slog.Level
+	redaction    Redaction
+}
+
+// LoggerOption is an option passed to the WithLogger function used to change
+// the logging configuration
+type LoggerOption func(*loggerConfig)
+
+// WithLogLevels sets the level calls are logged at. Successful calls default
+// to slog.LevelInfo and failed ones to slog.LevelError.
+func WithLogLevels(success, failure slog.Level) LoggerOption {
+	return func(c *loggerConfig) {
+		c.successLevel = success
+		c.failureLevel = failure
+	}
+}
+
+// WithRedaction sets how emails, IPs and names are written to the logs. It
+// defaults to RedactMask.
+func WithRedaction(r Redaction) LoggerOption {
+	return func(c *loggerConfig) {
+		c.redaction = r
+	}
+}
+
+// WithLogger logs every call made by the client services.
+//
+// Each call is logged with its service, operation, params, status, latency,
+// number of attempts and the remaining rate limit. Emails, IPs and names
+// found in the params and in the error messages are redacted and the
+// Authorization header, only logged at debug level along with the other
+// request headers, is always scrubbed.
+func WithLogger(logger *slog.Logger, options ...LoggerOption) func(*config) {
+	return func(c *config) {
+		lc := &loggerConfig{
+			logger:       logger,
+			successLevel: slog.LevelInfo,
+			failureLevel: slog.LevelError,
+		}
+		for _, option := range options {
+			option(lc)
+		}
+		c.logger = lc
+	}
+}
+
+// middleware returns the Middleware logging each call
+func (lc *loggerConfig) middleware(next Handler) Handler {
+	return func(call *Call) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(call)
+
+		level := lc.successLevel
+		if err != nil {
+			level = lc.failureLevel
+		}
+		ctx := call.Context
+		if !lc.logger.Enabled(ctx, level) {
+			return resp, err
+		}
+
+		attrs := []slog.Attr{
+			slog.String("service", call.Service),
+			slog.String("operation", call.Operation),
+			lc.params(call.Params),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("attempts", call.Attempts),
+		}
+		if resp != nil {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if remaining := resp.Header.Get(rateLimitRemainingHeader); remaining != "" {
+				attrs = append(attrs, slog.String("rate_limit_remaining", remaining))
+			}
+			if resp.Request != nil && lc.logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs, slog.Any("request_header", scrubHeader(resp.Request.Header)))
+			}
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", lc.redactError(call.Params, scrubError(err))))
+		}
+
+		lc.logger.LogAttrs(ctx, level, "clearbit call", attrs...)
+		return resp, err
+	}
+}
+
+// params returns the params of a call as a log attribute with the personal
+// data redacted.
+func (lc *loggerConfig) params(params interface{}) slog.Attr {
+	switch p := params.(type) {
+	case PersonFindParams:
+		return slog.Group("params",
+			slog.String("email", lc.email(p.Email)),
+		)
+	case RiskCalculateParams:
+		return slog.Group("params",
+			slog.String("email", lc.email(p.Email)),
+			slog.String("ip", lc.ip(p.IP)),
+			slog.String("country_code", p.CountryCode),
+			slog.String("zip_code", p.ZipCode),
+			slog.String("given_name", lc.name(p.GivenName)),
+			slog.String("family_name", lc.name(p.FamilyName)),
+			slog.String("name", lc.name(p.Name)),
+		)
+	case RevealFindParams:
+		return slog.Group("params",
+			slog.String("ip", lc.ip(p.IP)),
+		)
+	case ProspectorSearchParams:
+		p.Name = lc.name(p.Name)
+		return slog.Any("params", p)
+	}
+	return slog.Any("params", params)
+}
+
+func (lc *loggerConfig) email(email string) string {
+	if email == "" {
+		return ""
+	}
+	if lc.redaction == RedactHash {
+		return hashPII(email)
+	}
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		return "***" + email[at:]
+	}
+	return redacted
+}
+
+func (lc *loggerConfig) ip(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	if lc.redaction == RedactHash {
+		return hashPII(ip)
+	}
+	if v4 := net.ParseIP(ip).To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+	return redacted
+}
+
+func (lc *loggerConfig) name(name string) string {
+	if name == "" {
+		return ""
+	}
+	if lc.redaction == RedactHash {
+		return hashPII(name)
+	}
+	return redacted
+}
+
+var (
+	emailPattern = `[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`
+	ipv4Pattern  = regexp.MustCompile(`^(?:\d{1,3}\.){3}\d{1,3}$`)
+	piiPattern   = regexp.MustCompile(emailPattern + `|\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+)
+
+// redactError redacts the personal data of an error message, as API errors
+// often echo the email or IP that was looked up. The personal values of the
+// params and any other email or IPv4 address are redacted like in the params.
+// Everything is replaced in a single pass so redacted values are not matched
+// again.
+func (lc *loggerConfig) redactError(params interface{}, message string) string {
+	redact := make(map[string]func(string) string)
+	add := func(f func(string) string, values ...string) {
+		for _, v := range values {
+			if v != "" {
+				redact[v] = f
+			}
+		}
+	}
+	switch p := params.(type) {
+	case PersonFindParams:
+		add(lc.email, p.Email)
+	case RiskCalculateParams:
+		add(lc.email, p.Email)
+		add(lc.ip, p.IP)
+		add(lc.name, p.GivenName, p.FamilyName, p.Name)
+	case RevealFindParams:
+		add(lc.ip, p.IP)
+	case ProspectorSearchParams:
+		add(lc.name, p.Name)
+	}
+
+	pattern := piiPattern
+	if len(redact) > 0 {
+		values := make([]string, 0, len(redact))
+		for v := range redact {
+			values = append(values, v)
+		}
+		// Longest first so a value is not cut short by one of its prefixes
+		sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+		for i, v := range values {
+			values[i] = regexp.QuoteMeta(v)
+		}
+		pattern = regexp.MustCompile(strings.Join(values, "|") + "|" + piiPattern.String())
+	}
+
+	return pattern.ReplaceAllStringFunc(message, func(match string) string {
+		if f, ok := redact[match]; ok {
+			return f(match)
+		}
+		if ipv4Pattern.MatchString(match) {
+			if net.ParseIP(match) == nil {
+				return match
+			}
+			return lc.ip(match)
+		}
+		return lc.email(match)
+	})
+}
+
+// hashPII returns a short stable hash of a personal value
+func hashPII(v string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(v))))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// scrubHeader returns a copy of the header with the credentials removed
+func scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", redacted)
+	}
+	return h
+}
+
+// scrubError returns the error message without the query string of the
+// request URL, which holds the params of the call.
+func scrubError(err error) string {
+	var ue *url.Error
+	if !errors.As(err, &ue) {
+		return err.Error()
+	}
+	u, perr := url.Parse(ue.URL)
+	if perr != nil {
+		return ue.Op + " " + redacted + ": " + ue.Err.Error()
+	}
+	u.RawQuery = ""
+	u.User = nil
+	return ue.Op + " " + u.String() + ": " + ue.Err.Error()
+}
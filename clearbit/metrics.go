@@ -0,0 +1,297 @@
+package clearbit
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histogram buckets used when none are given to NewMetrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics collects request counts, latencies, error types and cache hits
+// for each Clearbit service.
+//
+// Attach it to a client with WithMetrics and expose it either by mounting it
+// as an http.Handler, serving the Prometheus text format, or through expvar
+// with Publish.
+type Metrics struct {
+	mu       sync.Mutex
+	buckets  []float64
+	requests map[requestKey]uint64
+	errors   map[errorKey]uint64
+	latency  map[latencyKey]*histogram
+	cache    map[string]*cacheCounts
+}
+
+type requestKey struct {
+	service   string
+	operation string
+	outcome   Outcome
+}
+
+type errorKey struct {
+	service string
+	kind    string
+}
+
+type latencyKey struct {
+	service string
+	outcome Outcome
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+type cacheCounts struct {
+	hits   uint64
+	misses uint64
+}
+
+// NewMetrics returns an empty Metrics collector. Latencies are recorded in
+// buckets with the given upper bounds in seconds, DefaultLatencyBuckets when
+// none are given.
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		buckets:  buckets,
+		requests: map[requestKey]uint64{},
+		errors:   map[errorKey]uint64{},
+		latency:  map[latencyKey]*histogram{},
+		cache:    map[string]*cacheCounts{},
+	}
+}
+
+// WithMetrics records the metrics of every call made by the client services
+// into m. The same Metrics can be shared by several clients.
+func WithMetrics(m *Metrics) func(*config) {
+	return func(c *config) {
+		c.metrics = m
+	}
+}
+
+// middleware returns the Middleware recording each call
+func (m *Metrics) middleware(next Handler) Handler {
+	return func(call *Call) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(call)
+		outcome := OutcomeOf(resp, err)
+		if call.DryRun {
+			// synthetic dry run results are not real successes either
+			outcome = OutcomeDryRun
+		}
+		m.observe(call, outcome, err, time.Since(start))
+		return resp, err
+	}
+}
+
+func (m *Metrics) observe(call *Call, outcome Outcome, err error, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{call.Service, call.Operation, outcome}]++
+
+	if outcome == OutcomeError {
+		m.errors[errorKey{call.Service, errorKind(err)}]++
+	}
+
+	lk := latencyKey{call.Service, outcome}
+	h, ok := m.latency[lk]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latency[lk] = h
+	}
+	seconds := d.Seconds()
+	for i, le := range m.buckets {
+		if seconds <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+
+	cc, ok := m.cache[call.Service]
+	if !ok {
+		cc = &cacheCounts{}
+		m.cache[call.Service] = cc
+	}
+	if call.Cached {
+		cc.hits++
+	} else {
+		cc.misses++
+	}
+}
+
+// errorKind returns the API error type, or "request" for errors that
+// happened before getting an answer from the API.
+func errorKind(err error) string {
+	var ae apiError
+	if errors.As(err, &ae) && !ae.Empty() {
+		return ae.Errors[0].Type
+	}
+	return "request"
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := &strings.Builder{}
+
+	b.WriteString("# HELP clearbit_requests_total Clearbit API calls by service, operation and outcome.\n")
+	b.WriteString("# TYPE clearbit_requests_total counter\n")
+	requests := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		requests = append(requests, k)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.service != b.service {
+			return a.service < b.service
+		}
+		if a.operation != b.operation {
+			return a.operation < b.operation
+		}
+		return a.outcome < b.outcome
+	})
+	for _, k := range requests {
+		fmt.Fprintf(b, "clearbit_requests_total{service=%s,operation=%s,outcome=%s} %d\n",
+			label(k.service), label(k.operation), label(string(k.outcome)), m.requests[k])
+	}
+
+	b.WriteString("# HELP clearbit_errors_total Clearbit API errors by service and error type.\n")
+	b.WriteString("# TYPE clearbit_errors_total counter\n")
+	errs := make([]errorKey, 0, len(m.errors))
+	for k := range m.errors {
+		errs = append(errs, k)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].service != errs[j].service {
+			return errs[i].service < errs[j].service
+		}
+		return errs[i].kind < errs[j].kind
+	})
+	for _, k := range errs {
+		fmt.Fprintf(b, "clearbit_errors_total{service=%s,type=%s} %d\n", label(k.service), label(k.kind), m.errors[k])
+	}
+
+	b.WriteString("# HELP clearbit_request_duration_seconds Clearbit API call latency by service and outcome.\n")
+	b.WriteString("# TYPE clearbit_request_duration_seconds histogram\n")
+	latencies := make([]latencyKey, 0, len(m.latency))
+	for k := range m.latency {
+		latencies = append(latencies, k)
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		if latencies[i].service != latencies[j].service {
+			return latencies[i].service < latencies[j].service
+		}
+		return latencies[i].outcome < latencies[j].outcome
+	})
+	for _, k := range latencies {
+		h := m.latency[k]
+		labels := "service=" + label(k.service) + ",outcome=" + label(string(k.outcome))
+		for i, le := range m.buckets {
+			fmt.Fprintf(b, "clearbit_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(b, "clearbit_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(b, "clearbit_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "clearbit_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+
+	b.WriteString("# HELP clearbit_cache_requests_total Clearbit API calls served from cache or not, by service.\n")
+	b.WriteString("# TYPE clearbit_cache_requests_total counter\n")
+	for _, service := range sortedKeys(m.cache) {
+		cc := m.cache[service]
+		fmt.Fprintf(b, "clearbit_cache_requests_total{service=%s,result=\"hit\"} %d\n", label(service), cc.hits)
+		fmt.Fprintf(b, "clearbit_cache_requests_total{service=%s,result=\"miss\"} %d\n", label(service), cc.misses)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Publish exports the metrics through expvar under the given name. As with
+// expvar.Publish, it panics if the name is already registered.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(m.snapshot))
+}
+
+// snapshot returns the metrics as nested maps for expvar
+func (m *Metrics) snapshot() interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	services := map[string]map[string]interface{}{}
+	service := func(name string) map[string]interface{} {
+		s, ok := services[name]
+		if !ok {
+			s = map[string]interface{}{
+				"requests": map[string]uint64{},
+				"errors":   map[string]uint64{},
+			}
+			services[name] = s
+		}
+		return s
+	}
+
+	for k, n := range m.requests {
+		service(k.service)["requests"].(map[string]uint64)[k.operation+"."+string(k.outcome)] += n
+	}
+	for k, n := range m.errors {
+		service(k.service)["errors"].(map[string]uint64)[k.kind] += n
+	}
+	for k, h := range m.latency {
+		s := service(k.service)
+		latency, ok := s["latency_seconds"].(map[string]interface{})
+		if !ok {
+			latency = map[string]interface{}{}
+			s["latency_seconds"] = latency
+		}
+		latency[string(k.outcome)] = map[string]interface{}{"count": h.count, "sum": h.sum}
+	}
+	for name, cc := range m.cache {
+		service(name)["cache"] = map[string]uint64{"hits": cc.hits, "misses": cc.misses}
+	}
+	return services
+}
+
+// labelEscaper escapes the characters not allowed in a Prometheus label value
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// label quotes and escapes a Prometheus label value
+func label(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
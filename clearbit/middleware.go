@@ -0,0 +1,291 @@
+package clearbit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dghubble/sling"
+)
+
+// Service names used in Call.Service. They match the keys accepted by
+// WithBaseURLs.
+const (
+	ServiceAutocomplete = "autocomplete"
+	ServicePerson       = "person"
+	ServiceCompany      = "company"
+	ServiceDiscovery    = "discovery"
+	ServiceProspector   = "prospector"
+	ServiceReveal       = "reveal"
+	ServiceRisk         = "risk"
+	ServiceNameToDomain = "nameToDomain"
+)
+
+// Outcome classifies the result of a call
+type Outcome string
+
+// Outcomes returned by OutcomeOf
+const (
+	OutcomeOK          Outcome = "ok"
+	OutcomeNotFound    Outcome = "not_found"
+	OutcomeQueued      Outcome = "queued"
+	OutcomeRateLimited Outcome = "rate_limited"
+	OutcomeDryRun      Outcome = "dry_run"
+	OutcomeError       Outcome = "error"
+)
+
+// OutcomeOf classifies the response and error returned by a Handler.
+//
+// Clearbit answers 202 Accepted while a lookup is queued and 404 Not Found
+// when there is nothing to return, both are reported separately from
+// errors, as are the calls of a client in dry run mode.
+func OutcomeOf(resp *http.Response, err error) Outcome {
+	var dryRun *ErrDryRun
+	if errors.As(err, &dryRun) {
+		return OutcomeDryRun
+	}
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return OutcomeNotFound
+		case http.StatusAccepted:
+			return OutcomeQueued
+		case http.StatusTooManyRequests:
+			return OutcomeRateLimited
+		}
+	}
+	if err != nil || (resp != nil && resp.StatusCode >= 400) {
+		return OutcomeError
+	}
+	return OutcomeOK
+}
+
+// Call describes a single API call as it goes through the middleware chain.
+type Call struct {
+	// Context is the context the request is sent with
+	Context context.Context
+	// Service is one of the Service constants
+	Service string
+	// Operation is the name of the service method in lower case: find,
+	// combined, search, suggest or calculate
+	Operation string
+	// Params is the params struct given to the service method, e.g.
+	// PersonFindParams
+	Params interface{}
+	// Result points to the value the response is decoded into, e.g. *Person.
+	// It is only populated once the next Handler returns.
+	Result interface{}
+	// Header holds additional headers sent with the request, replacing any
+	// header of the same name set by the client
+	Header http.Header
+	// Query holds additional query parameters sent with the request
+	Query url.Values
+	// Attempts is the number of http requests sent for this call
+	Attempts int
+	// Cached is set by middleware that filled Result without sending a
+	// request, e.g. from a cache.
+	Cached bool
+	// DryRun is set when the client records calls instead of sending them,
+	// see WithDryRun.
+	DryRun bool
+	// SkipCache asks caching middleware to send the request even if a
+	// cached result is available. It is advisory and only honoured by
+	// middleware that checks it, see SkipCache.
+	SkipCache bool
+
+	apiKey  string
+	timeout time.Duration
+
+	request *sling.Sling
+}
+
+// Handler performs a Call and returns the http response along with any error
+// from the request or the API.
+type Handler func(call *Call) (*http.Response, error)
+
+// Middleware wraps a Handler to add behavior before and after a Call, such as
+// logging, metrics or policy checks. A Middleware may also return without
+// calling next to short-circuit the request.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to every call made by the client services.
+//
+// The first middleware given is the outermost one: it sees the call first
+// and the result last. Calling WithMiddleware several times appends to the
+// chain.
+func WithMiddleware(middleware ...Middleware) func(*config) {
+	return func(c *config) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// chain returns the middleware enabled through the client options along with
+// the ones given to WithMiddleware.
+func (c *config) chain() []Middleware {
+	var chain []Middleware
+	if c.tracer != nil {
+		chain = append(chain, tracingMiddleware(c.tracer))
+	}
+	if c.logger != nil {
+		chain = append(chain, c.logger.middleware)
+	}
+	if c.metrics != nil {
+		chain = append(chain, c.metrics.middleware)
+	}
+	if c.budget != nil {
+		chain = append(chain, c.budget.middleware)
+	}
+	chain = append(chain, c.middleware...)
+	if c.keyPool != nil {
+		// innermost so every failover attempt is a new request
+		chain = append(chain, c.keyPool.middleware)
+	}
+	return chain
+}
+
+// validator is implemented by the params structs checked before sending a
+// call.
+type validator interface {
+	Validate() error
+}
+
+// dispatcher runs the calls of all the services through the middleware chain
+// down to the network.
+type dispatcher struct {
+	handler    Handler
+	httpClient *http.Client
+	dryRun     bool
+	validate   bool
+	strict     *strictDecoder
+}
+
+func newDispatcher(c *config) *dispatcher {
+	d := &dispatcher{
+		httpClient: c.httpClient,
+		dryRun:     c.dryRun != nil,
+		validate:   c.validate || c.dryRun != nil,
+		strict:     c.strict,
+	}
+	if d.strict != nil && d.strict.report == nil {
+		logger := slog.Default()
+		if c.logger != nil {
+			logger = c.logger.logger
+		}
+		d.strict.report = logUnknownFields(logger)
+	}
+
+	d.handler = d.send
+	if c.dryRun != nil {
+		d.handler = c.dryRun.send
+	}
+
+	middleware := c.chain()
+	for i := len(middleware) - 1; i >= 0; i-- {
+		d.handler = middleware[i](d.handler)
+	}
+	return d
+}
+
+// do validates the params of call when asked to, applies the call options and
+// runs it through the middleware chain. req is the request built by the
+// service and only sent if the call reaches the end of the chain.
+func (d *dispatcher) do(call *Call, req *sling.Sling, opts []CallOption) (*http.Response, error) {
+	if v, ok := call.Params.(validator); ok && d.validate {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	call.Context = context.Background()
+	call.Header = http.Header{}
+	call.Query = url.Values{}
+	call.DryRun = d.dryRun
+	call.request = req
+	for _, opt := range opts {
+		opt(call)
+	}
+
+	if call.timeout > 0 {
+		ctx, cancel := context.WithTimeout(call.Context, call.timeout)
+		defer cancel()
+		call.Context = ctx
+	}
+	return d.handler(call)
+}
+
+// request returns the http request of a call with the headers and query
+// parameters added by the call options and middleware.
+func request(call *Call) (*http.Request, error) {
+	req := call.request.New()
+	for key, values := range call.Header {
+		for i, value := range values {
+			if i == 0 {
+				req.Set(key, value)
+			} else {
+				req.Add(key, value)
+			}
+		}
+	}
+
+	if call.apiKey != "" {
+		req.SetBasicAuth(call.apiKey, "")
+	}
+
+	httpReq, err := req.Request()
+	if err != nil {
+		return nil, err
+	}
+	if len(call.Query) > 0 {
+		query := httpReq.URL.Query()
+		for key, values := range call.Query {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		httpReq.URL.RawQuery = query.Encode()
+	}
+	return httpReq.WithContext(call.Context), nil
+}
+
+// send is the last Handler of every chain, it sends the request and decodes
+// the response into call.Result, or into an apiError for non 2XX responses.
+func (d *dispatcher) send(call *Call) (*http.Response, error) {
+	req, err := request(call)
+	if err != nil {
+		return nil, err
+	}
+
+	call.Attempts++
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	// leave the body readable by the caller
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	ae := new(apiError)
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		err = json.NewDecoder(bytes.NewReader(body)).Decode(ae)
+		return resp, relevantError(err, *ae)
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(call.Result); err != nil {
+		return resp, err
+	}
+	if d.strict != nil {
+		d.strict.check(call, body)
+	}
+	return resp, nil
+}
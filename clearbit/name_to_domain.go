@@ -1,7 +1,11 @@
 package clearbit
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/dghubble/sling"
 )
@@ -9,8 +13,29 @@ import (
 // NameToDomain represents the company returned by a call to Find
 type NameToDomain struct {
 	Logo   string `json:"logo"`
-	Name   string `json:"string"`
+	Name   string `json:"name"`
 	Domain string `json:"domain"`
+	// Raw is the JSON payload the result was decoded from
+	Raw json.RawMessage `json:"-"`
+	// Extras holds the keys of Raw without a matching field
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// ErrNameNotFound is returned by NameToDomain lookups when no domain is known
+// for the name.
+type ErrNameNotFound struct {
+	Name string
+	// Err is the error returned by the API
+	Err error
+}
+
+func (e *ErrNameNotFound) Error() string {
+	return fmt.Sprintf("clearbit: no domain found for %q", e.Name)
+}
+
+// Unwrap returns the error returned by the API
+func (e *ErrNameNotFound) Unwrap() error {
+	return e.Err
 }
 
 // NameToDomainFindParams wraps the parameters needed to interact with the NameToDomain API
@@ -19,26 +44,82 @@ type NameToDomainFindParams struct {
 	Name string `url:"name"`
 }
 
+// Validate checks the params before they are sent
+func (p NameToDomainFindParams) Validate() error {
+	return required("name", p.Name)
+}
+
 // NameToDomainService gives access to the NameToDomain API.
 //
 // Our NameToDomain API takes a company name, and returns the domain associated with
 // that name.
 type NameToDomainService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newNameToDomainService(sling *sling.Sling, baseURL string) *NameToDomainService {
+func newNameToDomainService(sling *sling.Sling, baseURL string, d *dispatcher) *NameToDomainService {
 	return &NameToDomainService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/"),
+		dispatcher: d,
 	}
 }
 
-// Find takes a company name and returns the domain associated with that name
-func (s *NameToDomainService) Find(params NameToDomainFindParams) (*NameToDomain, *http.Response, error) {
+// Find takes a company name and returns the domain associated with that
+// name. Unknown names return a nil result and an ErrNameNotFound.
+func (s *NameToDomainService) Find(params NameToDomainFindParams, opts ...CallOption) (*NameToDomain, *http.Response, error) {
 	item := new(NameToDomain)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("domains/find").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceNameToDomain, Operation: "find", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("domains/find").QueryStruct(params), opts)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, resp, &ErrNameNotFound{Name: params.Name, Err: err}
+	}
+	return item, resp, err
+}
+
+// DefaultBatchConcurrency is the number of concurrent calls made by
+// FindDomains when not given a concurrency
+const DefaultBatchConcurrency = 5
+
+// NameToDomainResult is the result of the lookup of one name by FindDomains
+type NameToDomainResult struct {
+	Name string
+	// Result is nil when Err is not
+	Result *NameToDomain
+	Err    error
+}
+
+// FindDomains looks up the domains of many company names with f, making at
+// most concurrency calls at a time. The results are in the order of names.
+// Once ctx is done the remaining names are not looked up and fail with the
+// error of ctx.
+func FindDomains(ctx context.Context, f NameToDomainFinder, names []string, concurrency int, opts ...CallOption) []NameToDomainResult {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	opts = append([]CallOption{WithContext(ctx)}, opts...)
+
+	results := make([]NameToDomainResult, len(names))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		results[i].Name = name
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *NameToDomainResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			r.Result, _, r.Err = f.Find(NameToDomainFindParams{Name: r.Name}, opts...)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
 }
@@ -0,0 +1,135 @@
+package nullable
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+)
+
+// Company is a clearbit.Company whose numeric and boolean attributes are
+// Optional.
+type Company struct {
+	ID             string                      `json:"id"`
+	Name           string                      `json:"name"`
+	LegalName      string                      `json:"legalName"`
+	Domain         string                      `json:"domain"`
+	DomainAliases  []string                    `json:"domainAliases"`
+	Site           clearbit.CompanySite        `json:"site"`
+	Category       clearbit.CompanyCategory    `json:"category"`
+	Tags           []string                    `json:"tags"`
+	Description    string                      `json:"description"`
+	FoundedYear    Optional[int]               `json:"foundedYear"`
+	Location       string                      `json:"location"`
+	TimeZone       string                      `json:"timeZone"`
+	UtcOffset      Optional[int]               `json:"utcOffset"`
+	Geo            CompanyGeo                  `json:"geo"`
+	Logo           string                      `json:"logo"`
+	Facebook       CompanyFacebook             `json:"facebook"`
+	LinkedIn       clearbit.SocialHandle       `json:"linkedin"`
+	Twitter        CompanyTwitter              `json:"twitter"`
+	Crunchbase     clearbit.SocialHandle       `json:"crunchbase"`
+	EmailProvider  Optional[bool]              `json:"emailProvider"`
+	Type           string                      `json:"type"`
+	Ticker         string                      `json:"ticker"`
+	Identifiers    clearbit.CompanyIdentifiers `json:"identifiers"`
+	Phone          string                      `json:"phone"`
+	Metrics        CompanyMetrics              `json:"metrics"`
+	IndexedAt      time.Time                   `json:"indexedAt"`
+	Fuzzy          Optional[bool]              `json:"fuzzy"`
+	Tech           []string                    `json:"tech"`
+	TechCategories []string                    `json:"techCategories"`
+	Parent         clearbit.CompanyParent      `json:"parent"`
+	UltimateParent clearbit.CompanyParent      `json:"ultimateParent"`
+}
+
+// CompanyGeo is a clearbit.CompanyGeo with Optional coordinates
+type CompanyGeo struct {
+	StreetNumber  string            `json:"streetNumber"`
+	StreetName    string            `json:"streetName"`
+	SubPremise    string            `json:"subPremise"`
+	StreetAddress string            `json:"streetAddress"`
+	City          string            `json:"city"`
+	PostalCode    string            `json:"postalCode"`
+	State         string            `json:"state"`
+	StateCode     string            `json:"stateCode"`
+	Country       string            `json:"country"`
+	CountryCode   string            `json:"countryCode"`
+	Lat           Optional[float64] `json:"lat"`
+	Lng           Optional[float64] `json:"lng"`
+}
+
+// CompanyFacebook is a clearbit.CompanyFacebook with Optional counts
+type CompanyFacebook struct {
+	Handle string        `json:"handle"`
+	Likes  Optional[int] `json:"likes"`
+}
+
+// CompanyTwitter is a clearbit.CompanyTwitter with Optional counts
+type CompanyTwitter struct {
+	Handle    string        `json:"handle"`
+	ID        string        `json:"id"`
+	Bio       string        `json:"bio"`
+	Followers Optional[int] `json:"followers"`
+	Following Optional[int] `json:"following"`
+	Statuses  Optional[int] `json:"statuses"`
+	Location  string        `json:"location"`
+	Site      string        `json:"site"`
+	Avatar    string        `json:"avatar"`
+}
+
+// CompanyMetrics is a clearbit.CompanyMetrics with Optional figures
+type CompanyMetrics struct {
+	AlexaUsRank            Optional[int] `json:"alexaUsRank"`
+	AlexaGlobalRank        Optional[int] `json:"alexaGlobalRank"`
+	TrafficRank            string        `json:"trafficRank"`
+	Employees              Optional[int] `json:"employees"`
+	EmployeesRange         string        `json:"employeesRange"`
+	MarketCap              Optional[int] `json:"marketCap"`
+	Raised                 Optional[int] `json:"raised"`
+	AnnualRevenue          Optional[int] `json:"annualRevenue"`
+	EstimatedAnnualRevenue string        `json:"estimatedAnnualRevenue"`
+	FiscalYearEnd          Optional[int] `json:"fiscalYearEnd"`
+}
+
+// FromCompany decodes the payload c was decoded from into a Company. When c
+// has no Raw payload, e.g. because it was built by hand, every attribute of c
+// is considered present, zero values included.
+func FromCompany(c *clearbit.Company) (*Company, error) {
+	data, err := payload(c.Raw, c)
+	if err != nil {
+		return nil, err
+	}
+	nc := new(Company)
+	if err := json.Unmarshal(data, nc); err != nil {
+		return nil, err
+	}
+	return nc, nil
+}
+
+// Flat converts c to a clearbit.Company, missing attributes becoming zero
+// values.
+func (c *Company) Flat() (*clearbit.Company, error) {
+	flat := new(clearbit.Company)
+	if err := convert(c, flat); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// payload returns raw, or the JSON encoding of v when raw is empty
+func payload(raw json.RawMessage, v interface{}) ([]byte, error) {
+	if len(raw) > 0 {
+		return raw, nil
+	}
+	return json.Marshal(v)
+}
+
+// convert copies from into to through their JSON encoding
+func convert(from, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
@@ -0,0 +1,38 @@
+package nullable_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+	"github.com/clearbit/clearbit-go/clearbit/nullable"
+)
+
+func ExampleFromCompany_output() {
+	var company clearbit.Company
+	_ = json.Unmarshal([]byte(`{
+		"domain": "clearbit.com",
+		"foundedYear": null,
+		"metrics": {"employees": 0, "raised": null}
+	}`), &company)
+
+	c, _ := nullable.FromCompany(&company)
+
+	employees, ok := c.Metrics.Employees.Get()
+	fmt.Println("employees:", employees, ok)
+	fmt.Println("raised:", c.Metrics.Raised.Valid)
+	fmt.Println("founded:", c.FoundedYear.Or(-1))
+
+	metrics, _ := json.Marshal(c.Metrics)
+	fmt.Println(string(metrics))
+
+	flat, _ := c.Flat()
+	fmt.Println(flat.Domain, flat.Metrics.Employees, flat.Metrics.Raised)
+
+	// Output:
+	// employees: 0 true
+	// raised: false
+	// founded: -1
+	// {"alexaUsRank":null,"alexaGlobalRank":null,"trafficRank":"","employees":0,"employeesRange":"","marketCap":null,"raised":null,"annualRevenue":null,"estimatedAnnualRevenue":"","fiscalYearEnd":null}
+	// clearbit.com 0 0
+}
@@ -0,0 +1,82 @@
+/*
+Package nullable provides versions of the Clearbit enrichment types telling
+apart values missing from the API response from zero values.
+
+The numeric and boolean attributes of clearbit.Person and clearbit.Company
+are plain ints, floats and bools, so an unknown employee count and a count of
+0 are both decoded as 0. The Person and Company types of this package hold
+them in Optional values instead, which keep track of whether the attribute
+was present and round-trip JSON null faithfully.
+
+They are usually built from the payload of a result:
+
+	company, resp, err := client.Company.Find(clearbit.CompanyFindParams{Domain: "clearbit.com"})
+	...
+	c, err := nullable.FromCompany(company)
+	if employees, ok := c.Metrics.Employees.Get(); ok {
+	    ...
+	}
+
+and can be converted back to the flat types with Flat.
+*/
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional holds a value that may be missing
+type Optional[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Some returns an Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Valid: true}
+}
+
+// Get returns the value and whether it is present
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+// Or returns the value if it is present, def otherwise
+func (o Optional[T]) Or(def T) T {
+	if o.Valid {
+		return o.Value
+	}
+	return def
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if it is missing
+func (o Optional[T]) Ptr() *T {
+	if !o.Valid {
+		return nil
+	}
+	v := o.Value
+	return &v
+}
+
+// MarshalJSON encodes a missing value as null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON decodes null as a missing value
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		var zero T
+		o.Value, o.Valid = zero, false
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Valid = true
+	return nil
+}
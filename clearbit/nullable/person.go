@@ -0,0 +1,103 @@
+package nullable
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+)
+
+// Person is a clearbit.Person whose numeric and boolean attributes are
+// Optional.
+type Person struct {
+	ID            string                    `json:"id"`
+	Name          clearbit.PersonName       `json:"name"`
+	Email         string                    `json:"email"`
+	Gender        string                    `json:"gender"`
+	Location      string                    `json:"location"`
+	TimeZone      string                    `json:"timeZone"`
+	UTCOffset     Optional[int]             `json:"utcOffset"`
+	Geo           PersonGeo                 `json:"geo"`
+	Bio           string                    `json:"bio"`
+	Site          string                    `json:"site"`
+	Avatar        string                    `json:"avatar"`
+	Phone         string                    `json:"phone"`
+	Employment    clearbit.PersonEmployment `json:"employment"`
+	Facebook      clearbit.SocialHandle     `json:"facebook"`
+	GitHub        PersonGitHub              `json:"github"`
+	Twitter       PersonTwitter             `json:"twitter"`
+	LinkedIn      clearbit.SocialHandle     `json:"linkedin"`
+	GooglePlus    clearbit.SocialHandle     `json:"googleplus"`
+	AboutMe       clearbit.PersonAboutMe    `json:"aboutme"`
+	Gravatar      clearbit.PersonGravatar   `json:"gravatar"`
+	Fuzzy         Optional[bool]            `json:"fuzzy"`
+	EmailProvider Optional[bool]            `json:"emailProvider"`
+	IndexedAt     time.Time                 `json:"indexedAt"`
+	ActiveAt      time.Time                 `json:"activeAt"`
+	InactiveAt    time.Time                 `json:"inactiveAt"`
+}
+
+// PersonGeo is a clearbit.PersonGeo with Optional coordinates
+type PersonGeo struct {
+	StreetNumber string            `json:"streetNumber"`
+	StreetName   string            `json:"streetName"`
+	SubPremise   string            `json:"subPremise"`
+	City         string            `json:"city"`
+	PostalCode   string            `json:"postalCode"`
+	State        string            `json:"state"`
+	StateCode    string            `json:"stateCode"`
+	Country      string            `json:"country"`
+	CountryCode  string            `json:"countryCode"`
+	Lat          Optional[float64] `json:"lat"`
+	Lng          Optional[float64] `json:"lng"`
+}
+
+// PersonGitHub is a clearbit.PersonGitHub with Optional counts
+type PersonGitHub struct {
+	Handle    string        `json:"handle"`
+	ID        Optional[int] `json:"id"`
+	Avatar    string        `json:"avatar"`
+	Company   string        `json:"company"`
+	Blog      string        `json:"blog"`
+	Followers Optional[int] `json:"followers"`
+	Following Optional[int] `json:"following"`
+}
+
+// PersonTwitter is a clearbit.PersonTwitter with Optional counts
+type PersonTwitter struct {
+	Handle    string        `json:"handle"`
+	ID        Optional[int] `json:"id"`
+	Bio       string        `json:"bio"`
+	Followers Optional[int] `json:"followers"`
+	Following Optional[int] `json:"following"`
+	Statuses  Optional[int] `json:"statuses"`
+	Favorites Optional[int] `json:"favorites"`
+	Location  string        `json:"location"`
+	Site      string        `json:"site"`
+	Avatar    string        `json:"avatar"`
+}
+
+// FromPerson decodes the payload p was decoded from into a Person. When p
+// has no Raw payload every attribute of p is considered present, zero values
+// included.
+func FromPerson(p *clearbit.Person) (*Person, error) {
+	data, err := payload(p.Raw, p)
+	if err != nil {
+		return nil, err
+	}
+	np := new(Person)
+	if err := json.Unmarshal(data, np); err != nil {
+		return nil, err
+	}
+	return np, nil
+}
+
+// Flat converts p to a clearbit.Person, missing attributes becoming zero
+// values.
+func (p *Person) Flat() (*clearbit.Person, error) {
+	flat := new(clearbit.Person)
+	if err := convert(p, flat); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
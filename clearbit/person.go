@@ -1,6 +1,7 @@
 package clearbit
 
 import (
+	"encoding/json"
 	"net/http"
 	"time"
 
@@ -10,86 +11,116 @@ import (
 // Person contains all the person fields gathered from the Person json
 // structure. https://dashboard.clearbit.com/docs#enrichment-api-person-api
 type Person struct {
-	ID   string `json:"id"`
-	Name struct {
-		FullName   string `json:"fullName"`
-		GivenName  string `json:"givenName"`
-		FamilyName string `json:"familyName"`
-	} `json:"name"`
-	Email     string `json:"email"`
-	Gender    string `json:"gender"`
+	ID            string           `json:"id"`
+	Name          PersonName       `json:"name"`
+	Email         string           `json:"email"`
+	Gender        string           `json:"gender"`
+	Location      string           `json:"location"`
+	TimeZone      string           `json:"timeZone"`
+	UTCOffset     int              `json:"utcOffset"`
+	Geo           PersonGeo        `json:"geo"`
+	Bio           string           `json:"bio"`
+	Site          string           `json:"site"`
+	Avatar        string           `json:"avatar"`
+	Phone         string           `json:"phone"`
+	Employment    PersonEmployment `json:"employment"`
+	Facebook      SocialHandle     `json:"facebook"`
+	GitHub        PersonGitHub     `json:"github"`
+	Twitter       PersonTwitter    `json:"twitter"`
+	LinkedIn      SocialHandle     `json:"linkedin"`
+	GooglePlus    SocialHandle     `json:"googleplus"`
+	AboutMe       PersonAboutMe    `json:"aboutme"`
+	Gravatar      PersonGravatar   `json:"gravatar"`
+	Fuzzy         bool             `json:"fuzzy"`
+	EmailProvider bool             `json:"emailProvider"`
+	IndexedAt     time.Time        `json:"indexedAt"`
+	// ActiveAt and InactiveAt bound the period the person was seen active
+	// at their current employment
+	ActiveAt   time.Time `json:"activeAt"`
+	InactiveAt time.Time `json:"inactiveAt"`
+	// Raw is the JSON payload the person was decoded from
+	Raw json.RawMessage `json:"-"`
+	// Extras holds the keys of Raw without a matching field
+	Extras map[string]json.RawMessage `json:"-"`
+}
+
+// PersonName is the name of a person, as returned by the Person and
+// Prospector APIs
+type PersonName struct {
+	FullName   string `json:"fullName"`
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+}
+
+// PersonGeo is the location of a person broken down in its parts
+type PersonGeo struct {
+	StreetNumber string  `json:"streetNumber"`
+	StreetName   string  `json:"streetName"`
+	SubPremise   string  `json:"subPremise"`
+	City         string  `json:"city"`
+	PostalCode   string  `json:"postalCode"`
+	State        string  `json:"state"`
+	StateCode    string  `json:"stateCode"`
+	Country      string  `json:"country"`
+	CountryCode  string  `json:"countryCode"`
+	Lat          float64 `json:"lat"`
+	Lng          float64 `json:"lng"`
+}
+
+// PersonEmployment is the current employment of a person
+type PersonEmployment struct {
+	Domain    string    `json:"domain"`
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Role      Role      `json:"role"`
+	SubRole   SubRole   `json:"subRole"`
+	Seniority Seniority `json:"seniority"`
+}
+
+// PersonGitHub is the GitHub account of a person
+type PersonGitHub struct {
+	Handle    string `json:"handle"`
+	ID        int    `json:"id"`
+	Avatar    string `json:"avatar"`
+	Company   string `json:"company"`
+	Blog      string `json:"blog"`
+	Followers int    `json:"followers"`
+	Following int    `json:"following"`
+}
+
+// PersonTwitter is the Twitter account of a person
+type PersonTwitter struct {
+	Handle    string `json:"handle"`
+	ID        int    `json:"id"`
+	Bio       string `json:"bio"`
+	Followers int    `json:"followers"`
+	Following int    `json:"following"`
+	Statuses  int    `json:"statuses"`
+	Favorites int    `json:"favorites"`
 	Location  string `json:"location"`
-	TimeZone  string `json:"timeZone"`
-	UTCOffset int    `json:"utcOffset"`
-	Geo       struct {
-		City        string  `json:"city"`
-		State       string  `json:"state"`
-		StateCode   string  `json:"stateCode"`
-		Country     string  `json:"country"`
-		CountryCode string  `json:"countryCode"`
-		Lat         float64 `json:"lat"`
-		Lng         float64 `json:"lng"`
-	} `json:"geo"`
-	Bio        string `json:"bio"`
-	Site       string `json:"site"`
-	Avatar     string `json:"avatar"`
-	Employment struct {
-		Domain    string `json:"domain"`
-		Name      string `json:"name"`
-		Title     string `json:"title"`
-		Role      string `json:"role"`
-		Seniority string `json:"seniority"`
-	} `json:"employment"`
-	Facebook struct {
-		Handle string `json:"handle"`
-	} `json:"facebook"`
-	GitHub struct {
-		Handle    string `json:"handle"`
-		ID        int    `json:"id"`
-		Avatar    string `json:"avatar"`
-		Company   string `json:"company"`
-		Blog      string `json:"blog"`
-		Followers int    `json:"followers"`
-		Following int    `json:"following"`
-	} `json:"github"`
-	Twitter struct {
-		Handle    string `json:"handle"`
-		ID        int    `json:"id"`
-		Bio       string `json:"bio"`
-		Followers int    `json:"followers"`
-		Following int    `json:"following"`
-		Statuses  int    `json:"statuses"`
-		Favorites int    `json:"favorites"`
-		Location  string `json:"location"`
-		Site      string `json:"site"`
-		Avatar    string `json:"avatar"`
-	} `json:"twitter"`
-	LinkedIn struct {
-		Handle string `json:"handle"`
-	} `json:"linkedin"`
-	GooglePlus struct {
-		Handle string `json:"handle"`
-	} `json:"googleplus"`
-	AboutMe struct {
-		Handle string `json:"handle"`
-		Bio    string `json:"bio"`
-		Avatar string `json:"avatar"`
-	} `json:"aboutme"`
-	Gravatar struct {
-		Handle string `json:"handle"`
-		Urls   []struct {
-			URL  string `json:"url"`
-			Type string `json:"type"`
-		} `json:"urls"`
-		Avatar  string `json:"avatar"`
-		Avatars []struct {
-			URL  string `json:"url"`
-			Type string `json:"type"`
-		} `json:"avatars"`
-	} `json:"gravatar"`
-	Fuzzy         bool      `json:"fuzzy"`
-	EmailProvider bool      `json:"emailProvider"`
-	IndexedAt     time.Time `json:"indexedAt"`
+	Site      string `json:"site"`
+	Avatar    string `json:"avatar"`
+}
+
+// PersonAboutMe is the about.me profile of a person
+type PersonAboutMe struct {
+	Handle string `json:"handle"`
+	Bio    string `json:"bio"`
+	Avatar string `json:"avatar"`
+}
+
+// PersonGravatar is the Gravatar profile of a person
+type PersonGravatar struct {
+	Handle  string         `json:"handle"`
+	Urls    []GravatarLink `json:"urls"`
+	Avatar  string         `json:"avatar"`
+	Avatars []GravatarLink `json:"avatars"`
+}
+
+// GravatarLink is a link or an avatar listed on a Gravatar profile
+type GravatarLink struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
 }
 
 // PersonCompany represents the item returned by a call to FindCombined.
@@ -105,33 +136,40 @@ type PersonFindParams struct {
 	Email string `url:"email,omitempty"`
 }
 
+// Validate checks the params before they are sent
+func (p PersonFindParams) Validate() error {
+	return required("email", p.Email)
+}
+
 // PersonService gives access to the Person API.
 // https://dashboard.clearbit.com/docs#enrichment-api-person-api
 type PersonService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newPersonService(sling *sling.Sling, baseURL string) *PersonService {
+func newPersonService(sling *sling.Sling, baseURL string, d *dispatcher) *PersonService {
 	return &PersonService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v2/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v2/"),
+		dispatcher: d,
 	}
 }
 
-//Find looks up a person based on a email address
-func (s *PersonService) Find(params PersonFindParams) (*Person, *http.Response, error) {
+// Find looks up a person based on a email address
+func (s *PersonService) Find(params PersonFindParams, opts ...CallOption) (*Person, *http.Response, error) {
 	item := new(Person)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("people/find").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServicePerson, Operation: "find", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("people/find").QueryStruct(params), opts)
+	return item, resp, err
 }
 
-//FindCombined looks up a person and company simultaneously based on a email
-//address
-func (s *PersonService) FindCombined(params PersonFindParams) (*PersonCompany, *http.Response, error) {
+// FindCombined looks up a person and company simultaneously based on a email
+// address
+func (s *PersonService) FindCombined(params PersonFindParams, opts ...CallOption) (*PersonCompany, *http.Response, error) {
 	item := new(PersonCompany)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("combined/find").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServicePerson, Operation: "combined", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("combined/find").QueryStruct(params), opts)
+	return item, resp, err
 }
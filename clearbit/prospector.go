@@ -1,6 +1,7 @@
 package clearbit
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -10,49 +11,106 @@ const (
 	apiVersion = "2018-08-15"
 )
 
+// ProspectorResponse is a page of the contacts found by the Prospector API
 type ProspectorResponse struct {
-	Page     int `json:"page"`
-	PageSize int `json:"page_size"`
-	Total    int `json:"total"`
-	Results  []struct {
-		ID   string `json:"id"`
-		Name struct {
-			FullName   string `json:"fullName"`
-			GivenName  string `json:"givenName"`
-			FamilyName string `json:"familyName"`
-		} `json:"name"`
-		Title     string `json:"title"`
-		Role      string `json:"role"`
-		Seniority string `json:"seniority"`
-		Company   struct {
-			Name string `json:"name"`
-		} `json:"company"`
-		Email    string `json:"email"`
-		Location string `json:"location"`
-		Phone    string `json:"phone"`
-		Verified bool   `json:"verified"`
-	} `json:"results"`
+	Page     int        `json:"page"`
+	PageSize int        `json:"page_size"`
+	Total    int        `json:"total"`
+	Results  []Prospect `json:"results"`
+}
+
+// Prospect is a contact found by the Prospector API
+type Prospect struct {
+	ID        string     `json:"id"`
+	Name      PersonName `json:"name"`
+	Title     string     `json:"title"`
+	Role      Role       `json:"role"`
+	SubRole   SubRole    `json:"subRole"`
+	Seniority Seniority  `json:"seniority"`
+	Company   struct {
+		Name string `json:"name"`
+	} `json:"company"`
+	Email    string `json:"email"`
+	Location string `json:"location"`
+	Phone    string `json:"phone"`
+	Verified bool   `json:"verified"`
 }
 
 // ProspectorSearchParams wraps the parameters needed to interact with the
-// Prospector API
+// Prospector API. The single and plural forms of a filter, such as Role and
+// Roles, cannot be used together.
 type ProspectorSearchParams struct {
-	Domain      string   `url:"domain,omitempty"`
-	Role        string   `url:"role,omitempty"`
-	Roles       []string `url:"roles[],omitempty"`
-	Seniority   string   `url:"seniority,omitempty"`
-	Seniorities []string `url:"seniorities[],omitempty"`
-	Title       string   `url:"title,omitempty"`
-	Titles      []string `url:"titles[],omitempty"`
-	City        string   `url:"city,omitempty"`
-	Cities      []string `url:"cities[],omitempty"`
-	State       string   `url:"state,omitempty"`
-	States      []string `url:"states[],omitempty"`
-	Country     string   `url:"country,omitempty"`
-	Countries   []string `url:"countries[],omitempty"`
-	Name        string   `url:"name,omitempty"`
-	Page        int      `url:"page,omitempty"`
-	PageSize    int      `url:"page_size,omitempty"`
+	Domain      string      `url:"domain,omitempty"`
+	Role        Role        `url:"role,omitempty"`
+	Roles       []Role      `url:"roles[],omitempty"`
+	SubRole     SubRole     `url:"sub_role,omitempty"`
+	SubRoles    []SubRole   `url:"sub_roles[],omitempty"`
+	Seniority   Seniority   `url:"seniority,omitempty"`
+	Seniorities []Seniority `url:"seniorities[],omitempty"`
+	Title       string      `url:"title,omitempty"`
+	Titles      []string    `url:"titles[],omitempty"`
+	City        string      `url:"city,omitempty"`
+	Cities      []string    `url:"cities[],omitempty"`
+	State       string      `url:"state,omitempty"`
+	States      []string    `url:"states[],omitempty"`
+	Country     string      `url:"country,omitempty"`
+	Countries   []string    `url:"countries[],omitempty"`
+	Name        string      `url:"name,omitempty"`
+	Page        int         `url:"page,omitempty"`
+	PageSize    int         `url:"page_size,omitempty"`
+}
+
+// MaxProspectorPageSize is the largest page size accepted by the Prospector
+// API
+const MaxProspectorPageSize = 20
+
+// Validate checks the params before they are sent
+func (p ProspectorSearchParams) Validate() error {
+	if err := required("domain", p.Domain); err != nil {
+		return err
+	}
+
+	exclusive := []struct {
+		single, plural string
+		both           bool
+	}{
+		{"role", "roles", p.Role != "" && len(p.Roles) > 0},
+		{"sub_role", "sub_roles", p.SubRole != "" && len(p.SubRoles) > 0},
+		{"seniority", "seniorities", p.Seniority != "" && len(p.Seniorities) > 0},
+		{"title", "titles", p.Title != "" && len(p.Titles) > 0},
+		{"city", "cities", p.City != "" && len(p.Cities) > 0},
+		{"state", "states", p.State != "" && len(p.States) > 0},
+		{"country", "countries", p.Country != "" && len(p.Countries) > 0},
+	}
+	for _, e := range exclusive {
+		if e.both {
+			return &ValidationError{Field: e.single, Message: "cannot be used with " + e.plural}
+		}
+	}
+
+	for _, r := range append([]Role{p.Role}, p.Roles...) {
+		if r != "" && !r.Valid() {
+			return &ValidationError{Field: "role", Message: fmt.Sprintf("unknown role %q", r)}
+		}
+	}
+	for _, r := range append([]SubRole{p.SubRole}, p.SubRoles...) {
+		if r != "" && !r.Valid() {
+			return &ValidationError{Field: "sub_role", Message: fmt.Sprintf("unknown sub-role %q", r)}
+		}
+	}
+	for _, s := range append([]Seniority{p.Seniority}, p.Seniorities...) {
+		if s != "" && !s.Valid() {
+			return &ValidationError{Field: "seniority", Message: fmt.Sprintf("unknown seniority %q", s)}
+		}
+	}
+
+	switch {
+	case p.Page < 0:
+		return &ValidationError{Field: "page", Message: "must not be negative"}
+	case p.PageSize < 0 || p.PageSize > MaxProspectorPageSize:
+		return &ValidationError{Field: "page_size", Message: fmt.Sprintf("must be between 1 and %d", MaxProspectorPageSize)}
+	}
+	return nil
 }
 
 // ProspectorService gives access to the Prospector API.
@@ -60,22 +118,24 @@ type ProspectorSearchParams struct {
 // The Prospector API lets you fetch contacts and emails associated with a
 // company, employment role, seniority, and job title.
 type ProspectorService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newProspectorService(sling *sling.Sling, baseURL string) *ProspectorService {
+func newProspectorService(sling *sling.Sling, baseURL string, d *dispatcher) *ProspectorService {
 	return &ProspectorService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/people/").Set("Api-Version", apiVersion),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/people/").Set("Api-Version", apiVersion),
+		dispatcher: d,
 	}
 }
 
 // Search lets you fetch contacts and emails associated with a company,
 // employment role, seniority, and job title.
-func (s *ProspectorService) Search(params ProspectorSearchParams) (ProspectorResponse, *http.Response, error) {
+func (s *ProspectorService) Search(params ProspectorSearchParams, opts ...CallOption) (ProspectorResponse, *http.Response, error) {
 	pr := new(ProspectorResponse)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("search").QueryStruct(params).Receive(pr, ae)
-	return *pr, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceProspector, Operation: "search", Params: params, Result: pr}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("search").QueryStruct(params), opts)
+	return *pr, resp, err
 }
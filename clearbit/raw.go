@@ -0,0 +1,85 @@
+package clearbit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// The Person, Company, Risk, Reveal and NameToDomain results keep the payload
+// they were decoded from in their Raw field, and the keys without a matching
+// Go field in their Extras field, so attributes added to the API are never
+// lost.
+
+// UnmarshalJSON decodes a Person, filling Raw and Extras
+func (p *Person) UnmarshalJSON(data []byte) error {
+	type person Person
+	if err := json.Unmarshal(data, (*person)(p)); err != nil {
+		return err
+	}
+	p.Raw, p.Extras = rawAndExtras(data, reflect.TypeOf(p))
+	return nil
+}
+
+// UnmarshalJSON decodes a Company, filling Raw and Extras
+func (c *Company) UnmarshalJSON(data []byte) error {
+	type company Company
+	if err := json.Unmarshal(data, (*company)(c)); err != nil {
+		return err
+	}
+	c.Raw, c.Extras = rawAndExtras(data, reflect.TypeOf(c))
+	return nil
+}
+
+// UnmarshalJSON decodes a Risk, filling Raw and Extras
+func (r *Risk) UnmarshalJSON(data []byte) error {
+	type risk Risk
+	if err := json.Unmarshal(data, (*risk)(r)); err != nil {
+		return err
+	}
+	r.Raw, r.Extras = rawAndExtras(data, reflect.TypeOf(r))
+	return nil
+}
+
+// UnmarshalJSON decodes a Reveal, filling Raw and Extras
+func (r *Reveal) UnmarshalJSON(data []byte) error {
+	type reveal Reveal
+	if err := json.Unmarshal(data, (*reveal)(r)); err != nil {
+		return err
+	}
+	r.Raw, r.Extras = rawAndExtras(data, reflect.TypeOf(r))
+	return nil
+}
+
+// UnmarshalJSON decodes a NameToDomain, filling Raw and Extras
+func (n *NameToDomain) UnmarshalJSON(data []byte) error {
+	type nameToDomain NameToDomain
+	if err := json.Unmarshal(data, (*nameToDomain)(n)); err != nil {
+		return err
+	}
+	n.Raw, n.Extras = rawAndExtras(data, reflect.TypeOf(n))
+	return nil
+}
+
+// rawAndExtras returns a copy of data and its top level keys that have no
+// field in the struct type t.
+func rawAndExtras(data []byte, t reflect.Type) (json.RawMessage, map[string]json.RawMessage) {
+	raw := append(json.RawMessage(nil), data...)
+
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return raw, nil
+	}
+
+	var extras map[string]json.RawMessage
+	fields := jsonFields(t.Elem())
+	for key, value := range keys {
+		if _, ok := lookupField(fields, key); ok {
+			continue
+		}
+		if extras == nil {
+			extras = map[string]json.RawMessage{}
+		}
+		extras[key] = value
+	}
+	return raw, extras
+}
@@ -0,0 +1,52 @@
+package replay_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/clearbit/clearbit-go/clearbit"
+	"github.com/clearbit/clearbit-go/clearbit/replay"
+)
+
+func ExampleNew_recordAndReplay_output() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"name": {"fullName": "Alex MacCaw"},
+			"email": "alex@clearbit.com"
+		}`))
+	}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "fixtures")
+	defer os.RemoveAll(dir)
+
+	urls := map[string]string{"person": server.URL}
+
+	recorder := clearbit.NewClient(
+		clearbit.WithAPIKey("sk_secret"),
+		clearbit.WithBaseURLs(urls),
+		clearbit.WithHTTPClient(&http.Client{Transport: replay.New(replay.Record, dir)}),
+	)
+	_, _, _ = recorder.Person.Find(clearbit.PersonFindParams{Email: "alex@clearbit.com"})
+
+	// the server is not needed anymore once the fixtures are recorded
+	server.Close()
+
+	player := clearbit.NewClient(
+		clearbit.WithBaseURLs(urls),
+		clearbit.WithHTTPClient(&http.Client{Transport: replay.New(replay.Replay, dir)}),
+	)
+	person, resp, _ := player.Person.Find(clearbit.PersonFindParams{Email: "alex@clearbit.com"})
+	fmt.Println(person.Name.FullName, person.Email, resp.Status)
+
+	_, _, err := player.Person.Find(clearbit.PersonFindParams{Email: "harlow@clearbit.com"})
+	fmt.Println(errors.Is(err, replay.ErrUnmatched))
+
+	// Output:
+	// Alex MacCaw sha256:64cc00c9a5647e68 200 OK
+	// true
+}
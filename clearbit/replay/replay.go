@@ -0,0 +1,295 @@
+/*
+Package replay provides an http.RoundTripper that records Clearbit API
+interactions to fixture files and serves them back in later runs.
+
+It is meant to be plugged into a Clearbit client through WithHTTPClient:
+
+	transport := replay.New(replay.Replay, "testdata/fixtures")
+	client := clearbit.NewClient(
+	    clearbit.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+
+In Record mode every request is sent to the real API and the request/response
+pair is written to the fixtures directory. The API key is never written to
+disk and the values of the configured PII fields are replaced by a hash, both
+in the query string and anywhere in the JSON response body.
+
+In Replay mode no request leaves the process. Requests are matched on method,
+path and normalized query and any request without a fixture fails with an
+error wrapping ErrUnmatched.
+*/
+package replay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Mode selects whether a Transport records or replays interactions
+type Mode int
+
+const (
+	// Replay serves previously recorded fixtures and never hits the network
+	Replay Mode = iota
+	// Record sends requests to the real API and saves the interactions
+	Record
+)
+
+// DefaultScrubFields are the query parameters and JSON keys scrubbed when no
+// other fields are configured with WithScrubFields.
+var DefaultScrubFields = []string{"email", "ip"}
+
+// ErrUnmatched is returned (wrapped) in Replay mode when a request has no
+// recorded fixture.
+var ErrUnmatched = errors.New("replay: no fixture matches request")
+
+// redacted replaces the API key wherever it appears in a recorded body.
+const redacted = "REDACTED"
+
+// Fixture is the on-disk representation of a recorded interaction
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest holds the parts of a request used for matching
+type FixtureRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query"`
+}
+
+// FixtureResponse holds the recorded response
+type FixtureResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	// Text holds the body of responses that are not JSON
+	Text string `json:"text,omitempty"`
+}
+
+// Transport is an http.RoundTripper recording or replaying Clearbit API
+// interactions.
+type Transport struct {
+	mode        Mode
+	dir         string
+	transport   http.RoundTripper
+	scrubFields map[string]bool
+}
+
+// Option is an option passed to the New function used to change the
+// Transport configuration
+type Option func(*Transport)
+
+// WithTransport sets the http.RoundTripper used to reach the real API in
+// Record mode. It defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(t *Transport) {
+		t.transport = rt
+	}
+}
+
+// WithScrubFields replaces DefaultScrubFields with the given list of query
+// parameters and JSON keys whose values must not be written to disk.
+func WithScrubFields(fields ...string) Option {
+	return func(t *Transport) {
+		t.scrubFields = map[string]bool{}
+		for _, f := range fields {
+			t.scrubFields[f] = true
+		}
+	}
+}
+
+// New returns a Transport in the given mode storing fixtures in dir.
+func New(mode Mode, dir string, options ...Option) *Transport {
+	t := &Transport{
+		mode:      mode,
+		dir:       dir,
+		transport: http.DefaultTransport,
+	}
+	WithScrubFields(DefaultScrubFields...)(t)
+
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := t.requestKey(req)
+
+	if t.mode == Record {
+		return t.record(req, key)
+	}
+	return t.replay(req, key)
+}
+
+func (t *Transport) record(req *http.Request, key FixtureRequest) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := Fixture{
+		Request: key,
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+		},
+	}
+	fixture.Response.Body, fixture.Response.Text = t.scrubBody(body, apiKey(req))
+	if err := t.save(fixture); err != nil {
+		return nil, err
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (t *Transport) replay(req *http.Request, key FixtureRequest) (*http.Response, error) {
+	data, err := ioutil.ReadFile(t.fixturePath(key))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s %s?%s", ErrUnmatched, key.Method, key.Path, key.Query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := Fixture{}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("replay: decoding %s: %v", t.fixturePath(key), err)
+	}
+
+	body := []byte(fixture.Response.Body)
+	if len(body) == 0 {
+		body = []byte(fixture.Response.Text)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.Response.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *Transport) save(fixture Fixture) error {
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(t.fixturePath(fixture.Request), data, 0644)
+}
+
+// requestKey returns the matching key of a request. Query parameters are
+// sorted and the values of scrubbed fields replaced by their hash so the
+// same request always produces the same key without storing PII.
+func (t *Transport) requestKey(req *http.Request) FixtureRequest {
+	query := url.Values{}
+	for name, values := range req.URL.Query() {
+		for _, v := range values {
+			if t.scrubFields[name] {
+				v = hash(v)
+			}
+			query.Add(name, v)
+		}
+	}
+	for _, values := range query {
+		sort.Strings(values)
+	}
+
+	return FixtureRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query.Encode(),
+	}
+}
+
+// fixturePath returns the file a request key is stored in
+func (t *Transport) fixturePath(key FixtureRequest) string {
+	name := strings.Trim(strings.Replace(key.Path, "/", "_", -1), "_")
+	sum := sha256.Sum256([]byte(key.Method + " " + key.Path + "?" + key.Query))
+	return filepath.Join(t.dir, fmt.Sprintf("%s_%s_%s.json", strings.ToLower(key.Method), name, hex.EncodeToString(sum[:6])))
+}
+
+// scrubBody removes the API key and the scrubbed fields from a JSON body.
+// Bodies that are not JSON are returned as text with only the API key
+// removed.
+func (t *Transport) scrubBody(body []byte, key string) (json.RawMessage, string) {
+	if key != "" {
+		body = bytes.Replace(body, []byte(key), []byte(redacted), -1)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, string(body)
+	}
+
+	scrubbed, err := json.Marshal(t.scrubValue(v))
+	if err != nil {
+		return nil, string(body)
+	}
+	return scrubbed, ""
+}
+
+func (t *Transport) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if s, ok := child.(string); ok && t.scrubFields[k] {
+				v[k] = hash(s)
+				continue
+			}
+			v[k] = t.scrubValue(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = t.scrubValue(child)
+		}
+	}
+	return v
+}
+
+// scrubHeader drops the headers that could carry credentials
+func scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	h.Del("Set-Cookie")
+	h.Del("Authorization")
+	return h
+}
+
+// apiKey returns the Clearbit API key sent through basic auth
+func apiKey(req *http.Request) string {
+	key, _, _ := req.BasicAuth()
+	return key
+}
+
+func hash(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
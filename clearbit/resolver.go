@@ -0,0 +1,241 @@
+package clearbit
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// The sources of the candidates of a Resolver
+const (
+	SourceAutocomplete = "autocomplete"
+	SourceNameToDomain = "nameToDomain"
+)
+
+// DefaultAmbiguityMargin is the score difference under which the two best
+// candidates of a resolution are considered ambiguous
+const DefaultAmbiguityMargin = 0.1
+
+// legalSuffixes are the words dropped from the end of company names before
+// comparing them
+var legalSuffixes = map[string]bool{
+	"ab": true, "ag": true, "bv": true, "co": true, "company": true,
+	"corp": true, "corporation": true, "gmbh": true, "inc": true,
+	"incorporated": true, "kg": true, "kk": true, "limited": true,
+	"llc": true, "llp": true, "lp": true, "ltd": true, "nv": true,
+	"oy": true, "plc": true, "pte": true, "pty": true, "sa": true,
+	"sarl": true, "sas": true, "spa": true, "srl": true,
+}
+
+// Candidate is a company a text may refer to
+type Candidate struct {
+	Domain string
+	Name   string
+	Logo   string
+	// Score ranks the candidate between 0 and 1
+	Score float64
+	// Sources lists the APIs that returned the candidate
+	Sources []string
+}
+
+// Resolution is the result of Resolver.Resolve
+type Resolution struct {
+	Query string
+	// Normalized is the query without punctuation nor legal suffixes
+	Normalized string
+	// Candidates are sorted by decreasing score
+	Candidates []Candidate
+	// Confidence is the score of the best candidate, 0 without candidates
+	Confidence float64
+	// Ambiguous is true when no candidate stands out
+	Ambiguous bool
+}
+
+// Best returns the best candidate, or false if there is none
+func (r *Resolution) Best() (Candidate, bool) {
+	if len(r.Candidates) == 0 {
+		return Candidate{}, false
+	}
+	return r.Candidates[0], true
+}
+
+// Resolver finds the domain of a company from a free text name, such as
+// "Acme Corp (US)", combining the Autocomplete and NameToDomain APIs.
+//
+// Candidates are scored on the similarity of their name with the text, the
+// agreement of both APIs and the presence of a logo.
+type Resolver struct {
+	Autocomplete Autocompleter
+	NameToDomain NameToDomainFinder
+	// AmbiguityMargin overrides DefaultAmbiguityMargin
+	AmbiguityMargin float64
+}
+
+// NewResolver returns a Resolver using the APIs of c
+func NewResolver(c *Client) *Resolver {
+	return &Resolver{Autocomplete: c.Autocomplete, NameToDomain: c.NameToDomain}
+}
+
+// Resolve returns the companies text may refer to. An error is only returned
+// when both APIs failed, a lookup without result is not an error.
+func (r *Resolver) Resolve(ctx context.Context, text string, opts ...CallOption) (*Resolution, error) {
+	opts = append([]CallOption{WithContext(ctx)}, opts...)
+	res := &Resolution{Query: text, Normalized: NormalizeCompanyName(text)}
+	query := res.Normalized
+	if query == "" {
+		query = strings.TrimSpace(text)
+	}
+
+	candidates := map[string]*Candidate{}
+	var order []string
+	add := func(source, domain, name, logo string) {
+		domain = strings.ToLower(domain)
+		if domain == "" {
+			return
+		}
+		c, ok := candidates[domain]
+		if !ok {
+			c = &Candidate{Domain: domain}
+			candidates[domain] = c
+			order = append(order, domain)
+		}
+		if c.Name == "" {
+			c.Name = name
+		}
+		if c.Logo == "" {
+			c.Logo = logo
+		}
+		c.Sources = append(c.Sources, source)
+	}
+
+	items, resp, acErr := r.Autocomplete.Suggest(AutocompleteSuggestParams{Query: query}, opts...)
+	if OutcomeOf(resp, acErr) == OutcomeNotFound {
+		acErr = nil
+	}
+	for _, item := range items {
+		add(SourceAutocomplete, item.Domain, item.Name, item.Logo)
+	}
+	// the first suggestion is the one Autocomplete ranks best
+	var top string
+	if len(items) > 0 {
+		top = strings.ToLower(items[0].Domain)
+	}
+
+	found, resp, ntdErr := r.NameToDomain.Find(NameToDomainFindParams{Name: query}, opts...)
+	if OutcomeOf(resp, ntdErr) == OutcomeNotFound {
+		ntdErr = nil
+	} else if ntdErr == nil {
+		add(SourceNameToDomain, found.Domain, found.Name, found.Logo)
+	}
+
+	if acErr != nil && ntdErr != nil {
+		return res, acErr
+	}
+
+	for _, domain := range order {
+		c := candidates[domain]
+		name := c.Name
+		if name == "" {
+			name = strings.SplitN(c.Domain, ".", 2)[0]
+		}
+		c.Score = 0.6 * nameSimilarity(res.Normalized, NormalizeCompanyName(name))
+		if len(c.Sources) > 1 {
+			c.Score += 0.25
+		}
+		if c.Logo != "" {
+			c.Score += 0.1
+		}
+		if c.Domain == top {
+			c.Score += 0.05
+		}
+		res.Candidates = append(res.Candidates, *c)
+	}
+	sort.SliceStable(res.Candidates, func(i, j int) bool {
+		return res.Candidates[i].Score > res.Candidates[j].Score
+	})
+
+	margin := r.AmbiguityMargin
+	if margin <= 0 {
+		margin = DefaultAmbiguityMargin
+	}
+	if best, ok := res.Best(); ok {
+		res.Confidence = best.Score
+		res.Ambiguous = best.Score < 0.5 ||
+			(len(res.Candidates) > 1 && best.Score-res.Candidates[1].Score < margin)
+	}
+	return res, nil
+}
+
+// NormalizeCompanyName lowercases a company name and removes its punctuation,
+// parenthesized parts and legal suffixes: "Acme Corp. (US)" becomes "acme".
+func NormalizeCompanyName(name string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			if depth > 0 {
+				depth--
+			}
+		case depth > 0:
+		case r == '&':
+			b.WriteString(" and ")
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '.' || r == '\'':
+			// "co." and "o'reilly" keep their word whole
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity returns the similarity of two normalized names between 0
+// and 1, based on their edit distance
+func nameSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
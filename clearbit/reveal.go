@@ -1,6 +1,8 @@
 package clearbit
 
 import (
+	"encoding/json"
+	"net"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -13,6 +15,10 @@ type Reveal struct {
 	Domain          string `json:"domain"`
 	Company         Company
 	ConfidenceScore int `json:"confidence_score,omitempty"`
+	// Raw is the JSON payload the reveal was decoded from
+	Raw json.RawMessage `json:"-"`
+	// Extras holds the keys of Raw without a matching field
+	Extras map[string]json.RawMessage `json:"-"`
 }
 
 // RevealFindParams wraps the parameters needed to interact with the Reveal API
@@ -21,26 +27,39 @@ type RevealFindParams struct {
 	IP string `url:"ip,omitempty"`
 }
 
+// Validate checks the params before they are sent
+func (p RevealFindParams) Validate() error {
+	if err := required("ip", p.IP); err != nil {
+		return err
+	}
+	if net.ParseIP(p.IP) == nil {
+		return &ValidationError{Field: "ip", Message: "is not an IP address"}
+	}
+	return nil
+}
+
 // RevealService gives access to the Reveal API.
 //
 // Our Reveal API takes an IP address, and returns the company associated with
 // that IP.
 type RevealService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newRevealService(sling *sling.Sling, baseURL string) *RevealService {
+func newRevealService(sling *sling.Sling, baseURL string, d *dispatcher) *RevealService {
 	return &RevealService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/companies/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/companies/"),
+		dispatcher: d,
 	}
 }
 
 // Find takes an IP address, and returns the company associated with that IP
-func (s *RevealService) Find(params RevealFindParams) (*Reveal, *http.Response, error) {
+func (s *RevealService) Find(params RevealFindParams, opts ...CallOption) (*Reveal, *http.Response, error) {
 	item := new(Reveal)
-	ae := new(apiError)
-	resp, err := s.sling.New().Get("find").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceReveal, Operation: "find", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Get("find").QueryStruct(params), opts)
+	return item, resp, err
 }
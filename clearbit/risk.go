@@ -1,6 +1,8 @@
 package clearbit
 
 import (
+	"encoding/json"
+	"net"
 	"net/http"
 
 	"github.com/dghubble/sling"
@@ -33,6 +35,10 @@ type Risk struct {
 		Score   int      `json:"score"`
 		Reasons []string `json:"reasons"`
 	} `json:"risk"`
+	// Raw is the JSON payload the risk was decoded from
+	Raw json.RawMessage `json:"-"`
+	// Extras holds the keys of Raw without a matching field
+	Extras map[string]json.RawMessage `json:"-"`
 }
 
 // RiskCalculateParams wraps the parameters needed to interact with the Risk API
@@ -47,27 +53,43 @@ type RiskCalculateParams struct {
 	Name        string `url:"name,omitempty"`
 }
 
+// Validate checks the params before they are sent
+func (p RiskCalculateParams) Validate() error {
+	if err := required("email", p.Email); err != nil {
+		return err
+	}
+	if err := required("ip", p.IP); err != nil {
+		return err
+	}
+	if net.ParseIP(p.IP) == nil {
+		return &ValidationError{Field: "ip", Message: "is not an IP address"}
+	}
+	return nil
+}
+
 // RiskService gives access to the Risk API.
 //
 // Our Risk API takes an email address, an IP address, and additional information
 // before returning a risk analysis for the user
 type RiskService struct {
-	baseSling *sling.Sling
-	sling     *sling.Sling
+	baseSling  *sling.Sling
+	sling      *sling.Sling
+	dispatcher *dispatcher
 }
 
-func newRiskService(sling *sling.Sling, baseURL string) *RiskService {
+func newRiskService(sling *sling.Sling, baseURL string, d *dispatcher) *RiskService {
 	return &RiskService{
-		baseSling: sling.New(),
-		sling:     sling.Base(baseURL).Path("/v1/"),
+		baseSling:  sling.New(),
+		sling:      sling.Base(baseURL).Path("/v1/"),
+		dispatcher: d,
 	}
 }
 
 // Find takes an email address, and an IP address, and returns the risk associated
 // with that user
-func (s *RiskService) Calculate(params RiskCalculateParams) (*Risk, *http.Response, error) {
+func (s *RiskService) Calculate(params RiskCalculateParams, opts ...CallOption) (*Risk, *http.Response, error) {
 	item := new(Risk)
-	ae := new(apiError)
-	resp, err := s.sling.New().Post("calculate").QueryStruct(params).Receive(item, ae)
-	return item, resp, relevantError(err, *ae)
+	call := &Call{Service: ServiceRisk, Operation: "calculate", Params: params, Result: item}
+	resp, err := s.dispatcher.do(call, s.sling.New().Post("calculate").QueryStruct(params), opts)
+	return item, resp, err
 }
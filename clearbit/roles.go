@@ -0,0 +1,177 @@
+package clearbit
+
+// Role is the employment role of a person, as used by the Person and
+// Prospector APIs
+type Role string
+
+// The employment roles known to Clearbit
+const (
+	RoleCEO                   = Role("ceo")
+	RoleCommunications        = Role("communications")
+	RoleConsulting            = Role("consulting")
+	RoleCustomerService       = Role("customer_service")
+	RoleEducation             = Role("education")
+	RoleEngineering           = Role("engineering")
+	RoleFinance               = Role("finance")
+	RoleFounder               = Role("founder")
+	RoleHealthProfessional    = Role("health_professional")
+	RoleHumanResources        = Role("human_resources")
+	RoleInformationTechnology = Role("information_technology")
+	RoleLegal                 = Role("legal")
+	RoleMarketing             = Role("marketing")
+	RoleOperations            = Role("operations")
+	RoleOwner                 = Role("owner")
+	RolePresident             = Role("president")
+	RoleProduct               = Role("product")
+	RolePublicRelations       = Role("public_relations")
+	RoleRealEstate            = Role("real_estate")
+	RoleRecruiting            = Role("recruiting")
+	RoleResearch              = Role("research")
+	RoleSales                 = Role("sales")
+)
+
+// Roles returns the employment roles known to Clearbit
+func Roles() []Role {
+	return append([]Role(nil), roles...)
+}
+
+var roles = []Role{
+	RoleCEO, RoleCommunications, RoleConsulting, RoleCustomerService,
+	RoleEducation, RoleEngineering, RoleFinance, RoleFounder,
+	RoleHealthProfessional, RoleHumanResources, RoleInformationTechnology,
+	RoleLegal, RoleMarketing, RoleOperations, RoleOwner, RolePresident,
+	RoleProduct, RolePublicRelations, RoleRealEstate, RoleRecruiting,
+	RoleResearch, RoleSales,
+}
+
+// Valid returns true for the roles listed in Roles
+func (r Role) Valid() bool {
+	return contains(roles, r)
+}
+
+// SubRole refines the Role of a person
+type SubRole string
+
+// The employment sub-roles known to Clearbit
+const (
+	SubRoleAccounting              = SubRole("accounting")
+	SubRoleAccounts                = SubRole("accounts")
+	SubRoleBrandMarketing          = SubRole("brand_marketing")
+	SubRoleBroadcasting            = SubRole("broadcasting")
+	SubRoleBusinessDevelopment     = SubRole("business_development")
+	SubRoleCompensation            = SubRole("compensation")
+	SubRoleContentMarketing        = SubRole("content_marketing")
+	SubRoleCustomerSuccess         = SubRole("customer_success")
+	SubRoleData                    = SubRole("data")
+	SubRoleDental                  = SubRole("dental")
+	SubRoleDevops                  = SubRole("devops")
+	SubRoleDoctor                  = SubRole("doctor")
+	SubRoleEditorial               = SubRole("editorial")
+	SubRoleEducationAdministration = SubRole("education_administration")
+	SubRoleElectrical              = SubRole("electrical")
+	SubRoleEmployeeDevelopment     = SubRole("employee_development")
+	SubRoleEvents                  = SubRole("events")
+	SubRoleFitness                 = SubRole("fitness")
+	SubRoleGraphicDesign           = SubRole("graphic_design")
+	SubRoleInformationSecurity     = SubRole("information_security")
+	SubRoleInstructor              = SubRole("instructor")
+	SubRoleInvestment              = SubRole("investment")
+	SubRoleJournalism              = SubRole("journalism")
+	SubRoleJudicial                = SubRole("judicial")
+	SubRoleLaboratory              = SubRole("laboratory")
+	SubRoleLogistics               = SubRole("logistics")
+	SubRoleMarketingCommunications = SubRole("marketing_communications")
+	SubRoleMechanical              = SubRole("mechanical")
+	SubRoleMediaRelations          = SubRole("media_relations")
+	SubRoleNetwork                 = SubRole("network")
+	SubRoleNursing                 = SubRole("nursing")
+	SubRoleOfficeManagement        = SubRole("office_management")
+	SubRoleParalegal               = SubRole("paralegal")
+	SubRolePipeline                = SubRole("pipeline")
+	SubRoleProduct                 = SubRole("product")
+	SubRoleProductDesign           = SubRole("product_design")
+	SubRoleProductMarketing        = SubRole("product_marketing")
+	SubRoleProfessor               = SubRole("professor")
+	SubRoleProjectEngineering      = SubRole("project_engineering")
+	SubRoleProjectManagement       = SubRole("project_management")
+	SubRolePropertyManagement      = SubRole("property_management")
+	SubRoleQualityAssurance        = SubRole("quality_assurance")
+	SubRoleRealtor                 = SubRole("realtor")
+	SubRoleRecruiting              = SubRole("recruiting")
+	SubRoleResearcher              = SubRole("researcher")
+	SubRoleSecurity                = SubRole("security")
+	SubRoleSoftware                = SubRole("software")
+	SubRoleSupport                 = SubRole("support")
+	SubRoleSystems                 = SubRole("systems")
+	SubRoleTax                     = SubRole("tax")
+	SubRoleTeacher                 = SubRole("teacher")
+	SubRoleTherapy                 = SubRole("therapy")
+	SubRoleVideo                   = SubRole("video")
+	SubRoleWeb                     = SubRole("web")
+	SubRoleWebDesign               = SubRole("web_design")
+	SubRoleWellness                = SubRole("wellness")
+	SubRoleWriting                 = SubRole("writing")
+)
+
+// SubRoles returns the employment sub-roles known to Clearbit
+func SubRoles() []SubRole {
+	return append([]SubRole(nil), subRoles...)
+}
+
+var subRoles = []SubRole{
+	SubRoleAccounting, SubRoleAccounts, SubRoleBrandMarketing,
+	SubRoleBroadcasting, SubRoleBusinessDevelopment, SubRoleCompensation,
+	SubRoleContentMarketing, SubRoleCustomerSuccess, SubRoleData,
+	SubRoleDental, SubRoleDevops, SubRoleDoctor, SubRoleEditorial,
+	SubRoleEducationAdministration, SubRoleElectrical,
+	SubRoleEmployeeDevelopment, SubRoleEvents, SubRoleFitness,
+	SubRoleGraphicDesign, SubRoleInformationSecurity, SubRoleInstructor,
+	SubRoleInvestment, SubRoleJournalism, SubRoleJudicial,
+	SubRoleLaboratory, SubRoleLogistics, SubRoleMarketingCommunications,
+	SubRoleMechanical, SubRoleMediaRelations, SubRoleNetwork,
+	SubRoleNursing, SubRoleOfficeManagement, SubRoleParalegal,
+	SubRolePipeline, SubRoleProduct, SubRoleProductDesign,
+	SubRoleProductMarketing, SubRoleProfessor, SubRoleProjectEngineering,
+	SubRoleProjectManagement, SubRolePropertyManagement,
+	SubRoleQualityAssurance, SubRoleRealtor, SubRoleRecruiting,
+	SubRoleResearcher, SubRoleSecurity, SubRoleSoftware, SubRoleSupport,
+	SubRoleSystems, SubRoleTax, SubRoleTeacher, SubRoleTherapy,
+	SubRoleVideo, SubRoleWeb, SubRoleWebDesign, SubRoleWellness,
+	SubRoleWriting,
+}
+
+// Valid returns true for the sub-roles listed in SubRoles
+func (r SubRole) Valid() bool {
+	return contains(subRoles, r)
+}
+
+// Seniority is the seniority of a person in their employment
+type Seniority string
+
+// The seniorities known to Clearbit
+const (
+	SeniorityExecutive = Seniority("executive")
+	SeniorityDirector  = Seniority("director")
+	SeniorityManager   = Seniority("manager")
+)
+
+// Seniorities returns the seniorities known to Clearbit
+func Seniorities() []Seniority {
+	return append([]Seniority(nil), seniorities...)
+}
+
+var seniorities = []Seniority{SeniorityExecutive, SeniorityDirector, SeniorityManager}
+
+// Valid returns true for the seniorities listed in Seniorities
+func (s Seniority) Valid() bool {
+	return contains(seniorities, s)
+}
+
+func contains[T comparable](list []T, v T) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
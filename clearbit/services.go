@@ -0,0 +1,55 @@
+package clearbit
+
+import "net/http"
+
+// PersonFinder is the interface implemented by PersonService.
+type PersonFinder interface {
+	Find(params PersonFindParams, opts ...CallOption) (*Person, *http.Response, error)
+	FindCombined(params PersonFindParams, opts ...CallOption) (*PersonCompany, *http.Response, error)
+}
+
+// CompanyFinder is the interface implemented by CompanyService.
+type CompanyFinder interface {
+	Find(params CompanyFindParams, opts ...CallOption) (*Company, *http.Response, error)
+}
+
+// DiscoverySearcher is the interface implemented by DiscoveryService.
+type DiscoverySearcher interface {
+	Search(params DiscoverySearchParams, opts ...CallOption) (*DiscoveryResults, *http.Response, error)
+}
+
+// ProspectorSearcher is the interface implemented by ProspectorService.
+type ProspectorSearcher interface {
+	Search(params ProspectorSearchParams, opts ...CallOption) (ProspectorResponse, *http.Response, error)
+}
+
+// RiskCalculator is the interface implemented by RiskService.
+type RiskCalculator interface {
+	Calculate(params RiskCalculateParams, opts ...CallOption) (*Risk, *http.Response, error)
+}
+
+// RevealFinder is the interface implemented by RevealService.
+type RevealFinder interface {
+	Find(params RevealFindParams, opts ...CallOption) (*Reveal, *http.Response, error)
+}
+
+// Autocompleter is the interface implemented by AutocompleteService.
+type Autocompleter interface {
+	Suggest(params AutocompleteSuggestParams, opts ...CallOption) ([]AutocompleteItem, *http.Response, error)
+}
+
+// NameToDomainFinder is the interface implemented by NameToDomainService.
+type NameToDomainFinder interface {
+	Find(params NameToDomainFindParams, opts ...CallOption) (*NameToDomain, *http.Response, error)
+}
+
+var (
+	_ PersonFinder       = (*PersonService)(nil)
+	_ CompanyFinder      = (*CompanyService)(nil)
+	_ DiscoverySearcher  = (*DiscoveryService)(nil)
+	_ ProspectorSearcher = (*ProspectorService)(nil)
+	_ RiskCalculator     = (*RiskService)(nil)
+	_ RevealFinder       = (*RevealService)(nil)
+	_ Autocompleter      = (*AutocompleteService)(nil)
+	_ NameToDomainFinder = (*NameToDomainService)(nil)
+)
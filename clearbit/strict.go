@@ -0,0 +1,211 @@
+package clearbit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownField is a JSON key of an API response that is not represented in
+// the Go type it was decoded into.
+type UnknownField struct {
+	Service   string
+	Operation string
+	// Path locates the key in the response, e.g. "company.metrics.trafficRank"
+	// or "results[].site.title"
+	Path string
+}
+
+// strictDecoder reports the unknown fields of the responses
+type strictDecoder struct {
+	report func(UnknownField)
+}
+
+// WithStrictDecoding reports every key of the API responses that is not
+// represented in the Go types, so changes of the API payloads are noticed.
+//
+// The unknown fields are given to report. When report is nil they are logged
+// as warnings to the logger of WithLogger, or to slog.Default().
+func WithStrictDecoding(report func(UnknownField)) func(*config) {
+	return func(c *config) {
+		c.strict = &strictDecoder{report: report}
+	}
+}
+
+// check reports the unknown fields of a response decoded for call
+func (s *strictDecoder) check(call *Call, body []byte) {
+	paths, err := unknownFields(body, reflect.TypeOf(call.Result))
+	if err != nil {
+		return
+	}
+	for _, path := range paths {
+		s.report(UnknownField{Service: call.Service, Operation: call.Operation, Path: path})
+	}
+}
+
+// logUnknownFields returns a report function logging to logger
+func logUnknownFields(logger *slog.Logger) func(UnknownField) {
+	return func(f UnknownField) {
+		logger.Warn("clearbit unknown field",
+			slog.String("service", f.Service),
+			slog.String("operation", f.Operation),
+			slog.String("path", f.Path),
+		)
+	}
+}
+
+// UnknownFields returns the path of every key of the JSON document data that
+// would be ignored when decoding it into v.
+func UnknownFields(data []byte, v interface{}) ([]string, error) {
+	return unknownFields(data, reflect.TypeOf(v))
+}
+
+// SchemaDrift lists the unknown fields of a sample payload
+type SchemaDrift struct {
+	File   string
+	Fields []string
+}
+
+// DetectSchemaDrift checks every .json file of dir against the type of v and
+// returns the files holding unknown fields. It is meant to be run from tests
+// on sample payloads saved from the API:
+//
+//	drift, err := clearbit.DetectSchemaDrift("testdata/company", clearbit.Company{})
+//	for _, d := range drift {
+//	    t.Errorf("%s: fields not in clearbit.Company: %v", d.File, d.Fields)
+//	}
+func DetectSchemaDrift(dir string, v interface{}) ([]SchemaDrift, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var drift []SchemaDrift
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := UnknownFields(data, v)
+		if err != nil {
+			return nil, fmt.Errorf("clearbit: %s: %v", file, err)
+		}
+		if len(fields) > 0 {
+			drift = append(drift, SchemaDrift{File: file, Fields: fields})
+		}
+	}
+	return drift, nil
+}
+
+func unknownFields(data []byte, t reflect.Type) ([]string, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	walkUnknown(v, t, "", &paths)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// unknownKeysKeeper is implemented by the types decoding the keys without a
+// field into a map, such as CompanyIdentifiers
+var unknownKeysKeeper = reflect.TypeOf((*interface{ keepsUnknownKeys() })(nil)).Elem()
+
+// walkUnknown appends to paths the keys of v that have no field in t
+func walkUnknown(v interface{}, t reflect.Type, path string, paths *[]string) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || reflect.PtrTo(t).Implements(unknownKeysKeeper) {
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			field, ok := lookupField(fields, key)
+			if !ok {
+				*paths = append(*paths, childPath)
+				continue
+			}
+			walkUnknown(child, field.Type, childPath, paths)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, child := range v {
+			walkUnknown(child, t.Elem(), path+"[]", paths)
+		}
+	}
+}
+
+// jsonFields returns the fields of a struct keyed by their JSON name,
+// including the fields promoted from embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" {
+			et := f.Type
+			if et.Kind() == reflect.Ptr {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				for k, ef := range jsonFields(et) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = ef
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// lookupField finds the field of a JSON key the way encoding/json does,
+// preferring an exact match over a case insensitive one.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
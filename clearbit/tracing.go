@@ -0,0 +1,119 @@
+package clearbit
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+)
+
+// traceparentHeader is the W3C Trace Context header carrying the span of the
+// caller. https://www.w3.org/TR/trace-context/
+const traceparentHeader = "traceparent"
+
+// Tracer starts the spans recording Clearbit calls.
+//
+// The interface is kept small so it can be implemented on top of any tracing
+// library. An OpenTelemetry adapter only has to wrap a trace.Tracer and
+// convert the trace and span IDs of the trace.SpanContext.
+type Tracer interface {
+	// Start starts a span as a child of any span found in ctx and returns a
+	// context holding the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced operation
+type Span interface {
+	SetAttribute(key string, value interface{})
+	AddEvent(name string)
+	RecordError(err error)
+	End()
+	// SpanContext identifies the span in the traceparent header of the
+	// outgoing request.
+	SpanContext() SpanContext
+}
+
+// SpanContext is the part of a span propagated to the Clearbit API
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid returns true when both the trace and span IDs are set
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceParent returns the value of the W3C traceparent header for sc
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(sc.TraceID[:]) + "-" + hex.EncodeToString(sc.SpanID[:]) + "-" + flags
+}
+
+// NoopTracer is a Tracer whose spans record nothing and are never
+// propagated.
+type NoopTracer struct{}
+
+// Start returns ctx unchanged and a span doing nothing
+func (NoopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) AddEvent(name string)                       {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+func (noopSpan) SpanContext() SpanContext                   { return SpanContext{} }
+
+// WithTracer traces every call made by the client services.
+//
+// Each call runs in a span named after its service and operation, e.g.
+// "clearbit.person.find", with the service, operation, status, outcome and
+// attempts as attributes. Queued and not found lookups are recorded as span
+// events and the span is propagated to Clearbit through the traceparent
+// header.
+func WithTracer(t Tracer) func(*config) {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// tracingMiddleware returns the Middleware tracing each call with t
+func tracingMiddleware(t Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(call *Call) (*http.Response, error) {
+			ctx, span := t.Start(call.Context, "clearbit."+call.Service+"."+call.Operation)
+			defer span.End()
+
+			call.Context = ctx
+			span.SetAttribute("clearbit.service", call.Service)
+			span.SetAttribute("clearbit.operation", call.Operation)
+			if sc := span.SpanContext(); sc.IsValid() {
+				call.Header.Set(traceparentHeader, sc.TraceParent())
+			}
+
+			resp, err := next(call)
+
+			outcome := OutcomeOf(resp, err)
+			span.SetAttribute("clearbit.outcome", string(outcome))
+			span.SetAttribute("clearbit.attempts", call.Attempts)
+			if resp != nil {
+				span.SetAttribute("http.status_code", resp.StatusCode)
+			}
+			switch outcome {
+			case OutcomeQueued, OutcomeNotFound:
+				span.AddEvent(string(outcome))
+			case OutcomeError, OutcomeRateLimited:
+				if err != nil {
+					span.RecordError(err)
+				}
+			}
+			return resp, err
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/clearbit/clearbit-go
 
-go 1.17
+go 1.21
 
 require github.com/dghubble/sling v1.1.0
 